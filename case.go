@@ -0,0 +1,142 @@
+package template
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SnakeCase return the words of s in lower case joined by underscores,
+// i.e: "HTTPServer" => "http_server".
+func SnakeCase(s string) string {
+	return joinWords(s, "_", strings.ToLower)
+}
+
+// KebabCase return the words of s in lower case joined by hyphens,
+// i.e: "HTTPServer" => "http-server".
+func KebabCase(s string) string {
+	return joinWords(s, "-", strings.ToLower)
+}
+
+// ScreamingSnakeCase return the words of s in upper case joined by underscores,
+// i.e: "HTTPServer" => "HTTP_SERVER".
+func ScreamingSnakeCase(s string) string {
+	return joinWords(s, "_", strings.ToUpper)
+}
+
+// PascalCase return the capitalized words of s joined together,
+// i.e: "http_server" => "HttpServer".
+func PascalCase(s string) string {
+	return joinWords(s, "", capitalize)
+}
+
+// CamelCase return the capitalized words of s joined together, except the first one
+// which is in lower case, i.e: "HTTPServer" => "httpServer".
+func CamelCase(s string) string {
+	rs := PascalCase(s)
+	if rs == "" {
+		return ""
+	}
+	r, n := utf8.DecodeRuneInString(rs)
+	return string(unicode.ToLower(r)) + rs[n:]
+}
+
+// SentenceCase return the words of s in lower case joined by spaces, with the first word
+// capitalized, i.e: "HTTPServer" => "Http server".
+func SentenceCase(s string) string {
+	rs := joinWords(s, " ", strings.ToLower)
+	if rs == "" {
+		return ""
+	}
+	r, n := utf8.DecodeRuneInString(rs)
+	return string(unicode.ToTitle(r)) + rs[n:]
+}
+
+// SwapCase return s with upper case letters converted to lower case and vice versa.
+func SwapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsUpper(r), unicode.IsTitle(r):
+			return unicode.ToLower(r)
+		case unicode.IsLower(r):
+			return unicode.ToUpper(r)
+		}
+		return r
+	}, s)
+}
+
+// Title return s with the first letter of each word mapped to its title case.
+// Unlike strings.Title, the other letters are kept as is so acronyms are preserved,
+// and letters following an apostrophe don't start a new word, i.e: "o'neil's HTTP api" => "O'neil's HTTP Api".
+func Title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		start := !isWordRune(prev) && prev != '\'' && prev != '’'
+		prev = r
+		if start {
+			return unicode.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+// splitWords splits s into words. Words are separated by non alphanumeric characters
+// and by case changes, i.e: "fooBar", "HTTPServer" and "foo_bar-baz" are split into
+// "foo" "Bar", "HTTP" "Server" and "foo" "bar" "baz". Digits belong to the preceding word.
+func splitWords(s string) []string {
+	rs := []rune(s)
+	words := make([]string, 0)
+	start := -1
+	for i, r := range rs {
+		if !isWordRune(r) {
+			if start >= 0 {
+				words = append(words, string(rs[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && isWordBoundary(rs, i) {
+			words = append(words, string(rs[start:i]))
+			start = i
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(rs[start:]))
+	}
+	return words
+}
+
+// isWordBoundary report whether a new word starts at rs[i], which is preceded by a word rune.
+func isWordBoundary(rs []rune, i int) bool {
+	r, prev := rs[i], rs[i-1]
+	if !unicode.IsUpper(r) {
+		return false
+	}
+	// fooBar, base64Encode.
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	// HTTPServer: the last upper case letter of an acronym starts the next word.
+	return unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+func joinWords(s string, sep string, f func(string) string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = f(w)
+	}
+	return strings.Join(words, sep)
+}
+
+// capitalize return the word with its first letter in upper case and the others in lower case.
+func capitalize(w string) string {
+	r, n := utf8.DecodeRuneInString(w)
+	return string(unicode.ToUpper(r)) + strings.ToLower(w[n:])
+}
@@ -0,0 +1,252 @@
+package template
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+type (
+	// Certificate is a PEM encoded certificate and its PEM encoded private key.
+	Certificate struct {
+		Cert string
+		Key  string
+	}
+
+	// PEMBlock is a decoded PEM block.
+	PEMBlock struct {
+		Type    string
+		Headers map[string]string
+		Bytes   []byte
+	}
+)
+
+const (
+	rsaKeyBits = 2048
+)
+
+var (
+	errInvalidPEM = errors.New("invalid PEM data")
+)
+
+// CertFuncMap return key and certificate generating func map.
+func CertFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"gen_private_key":      GenPrivateKey,
+		"gen_ca":               GenCA,
+		"gen_self_signed_cert": GenSelfSignedCert,
+		"gen_signed_cert":      GenSignedCert,
+		"pem_encode":           PEMEncode,
+		"pem_decode":           PEMDecode,
+	}
+}
+
+// GenPrivateKey return a new PEM encoded PKCS #8 private key of the given algorithm:
+// rsa (2048 bits), ecdsa (P-256) or ed25519.
+func GenPrivateKey(algorithm string) (string, error) {
+	key, err := newPrivateKey(algorithm)
+	if err != nil {
+		return "", err
+	}
+	return encodePrivateKey(key)
+}
+
+// GenCA return a new certificate authority valid for the given number of days.
+// The optional key is a PEM encoded private key, a new RSA key is generated if it is not provided.
+func GenCA(cn string, days int, key ...string) (Certificate, error) {
+	tmpl, err := newCertTemplate(cn, nil, nil, days)
+	if err != nil {
+		return Certificate{}, err
+	}
+	tmpl.IsCA = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	return genCert(tmpl, key, nil, nil)
+}
+
+// GenSelfSignedCert return a new self-signed certificate valid for the given number of days.
+// The ips and dnsNames are lists of the subject alternative names, and can be nil.
+// The optional key is a PEM encoded private key, a new RSA key is generated if it is not provided.
+func GenSelfSignedCert(cn string, ips interface{}, dnsNames interface{}, days int, key ...string) (Certificate, error) {
+	tmpl, err := newLeafTemplate(cn, ips, dnsNames, days)
+	if err != nil {
+		return Certificate{}, err
+	}
+	return genCert(tmpl, key, nil, nil)
+}
+
+// GenSignedCert return a new certificate valid for the given number of days signed by the CA,
+// i.e: gen_signed_cert "localhost" (list "127.0.0.1") (list "localhost") 365 $ca.
+// The optional key is a PEM encoded private key, a new RSA key is generated if it is not provided.
+func GenSignedCert(cn string, ips interface{}, dnsNames interface{}, days int, ca Certificate, key ...string) (Certificate, error) {
+	tmpl, err := newLeafTemplate(cn, ips, dnsNames, days)
+	if err != nil {
+		return Certificate{}, err
+	}
+	b, err := PEMDecode(ca.Cert)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	parent, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	parentKey, err := decodePrivateKey(ca.Key)
+	if err != nil {
+		return Certificate{}, fmt.Errorf("invalid CA key: %w", err)
+	}
+	return genCert(tmpl, key, parent, parentKey)
+}
+
+// PEMEncode return the PEM encoding of the data, which can be a string or a byte slice,
+// using the given block type, i.e: "CERTIFICATE".
+func PEMEncode(typ string, data interface{}) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: toBytes(data)}))
+}
+
+// PEMDecode return the first PEM block of the data.
+func PEMDecode(data interface{}) (PEMBlock, error) {
+	b, _ := pem.Decode(toBytes(data))
+	if b == nil {
+		return PEMBlock{}, errInvalidPEM
+	}
+	return PEMBlock{Type: b.Type, Headers: b.Headers, Bytes: b.Bytes}, nil
+}
+
+func newPrivateKey(algorithm string) (crypto.Signer, error) {
+	switch strings.ToLower(algorithm) {
+	case "rsa":
+		return rsa.GenerateKey(crand.Reader, rsaKeyBits)
+	case "ecdsa":
+		return ecdsa.GenerateKey(elliptic.P256(), crand.Reader)
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(crand.Reader)
+		return key, err
+	}
+	return nil, fmt.Errorf("unsupported key algorithm: %q", algorithm)
+}
+
+func encodePrivateKey(key crypto.Signer) (string, error) {
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return PEMEncode("PRIVATE KEY", b), nil
+}
+
+// decodePrivateKey decodes a PEM encoded PKCS #8, PKCS #1 or EC private key.
+func decodePrivateKey(s string) (crypto.Signer, error) {
+	b, err := PEMDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	var key interface{}
+	switch b.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(b.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(b.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(b.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type: %T", key)
+	}
+	return signer, nil
+}
+
+func newCertTemplate(cn string, ips interface{}, dnsNames interface{}, days int) (*x509.Certificate, error) {
+	if days <= 0 {
+		return nil, fmt.Errorf("invalid number of days: %d", days)
+	}
+	serial, err := crand.Int(crand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Duration(days) * 24 * time.Hour),
+		BasicConstraintsValid: true,
+	}
+	if ips != nil {
+		values, err := toSlice(ips)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < values.Len(); i++ {
+			s := fmt.Sprint(values.Index(i).Interface())
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address: %q", s)
+			}
+			tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+		}
+	}
+	if dnsNames != nil {
+		values, err := toSlice(dnsNames)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < values.Len(); i++ {
+			tmpl.DNSNames = append(tmpl.DNSNames, fmt.Sprint(values.Index(i).Interface()))
+		}
+	}
+	return tmpl, nil
+}
+
+func newLeafTemplate(cn string, ips interface{}, dnsNames interface{}, days int) (*x509.Certificate, error) {
+	tmpl, err := newCertTemplate(cn, ips, dnsNames, days)
+	if err != nil {
+		return nil, err
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	return tmpl, nil
+}
+
+// genCert creates the certificate signed by the parent, or self-signed if the parent is nil.
+func genCert(tmpl *x509.Certificate, keys []string, parent *x509.Certificate, parentKey crypto.Signer) (Certificate, error) {
+	var key crypto.Signer
+	var err error
+	if len(keys) > 0 {
+		key, err = decodePrivateKey(keys[0])
+	} else {
+		key, err = newPrivateKey("rsa")
+	}
+	if err != nil {
+		return Certificate{}, err
+	}
+	if _, ok := key.(*rsa.PrivateKey); !ok {
+		// key encipherment is only used with RSA keys.
+		tmpl.KeyUsage &^= x509.KeyUsageKeyEncipherment
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(crand.Reader, tmpl, parent, key.Public(), parentKey)
+	if err != nil {
+		return Certificate{}, err
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		return Certificate{}, err
+	}
+	return Certificate{Cert: PEMEncode("CERTIFICATE", der), Key: keyPEM}, nil
+}
@@ -0,0 +1,144 @@
+package template_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func parseCert(t *testing.T, s string) *x509.Certificate {
+	t.Helper()
+	b, _ := pem.Decode([]byte(s))
+	if b == nil || b.Type != "CERTIFICATE" {
+		t.Fatalf("got %q, want PEM certificate", s)
+	}
+	cert, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestGenPrivateKey(t *testing.T) {
+	verifyKey := func(algorithm string, check func(interface{}) bool) func(string) error {
+		return func(got string) error {
+			b, _ := pem.Decode([]byte(got))
+			if b == nil || b.Type != "PRIVATE KEY" {
+				return fmt.Errorf("got %q, want PEM private key", got)
+			}
+			key, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+			if err != nil || !check(key) {
+				return fmt.Errorf("got key=%T, err=%v, want %s key", key, err, algorithm)
+			}
+			return nil
+		}
+	}
+	testIt(t, []testCase{
+		{
+			name:       "rsa",
+			template:   `{{gen_private_key "rsa"}}`,
+			text:       true,
+			verifyFunc: verifyKey("rsa", func(k interface{}) bool { _, ok := k.(*rsa.PrivateKey); return ok }),
+		},
+		{
+			name:       "ecdsa",
+			template:   `{{gen_private_key "ecdsa"}}`,
+			text:       true,
+			verifyFunc: verifyKey("ecdsa", func(k interface{}) bool { _, ok := k.(*ecdsa.PrivateKey); return ok }),
+		},
+		{
+			name:       "ed25519",
+			template:   `{{gen_private_key "ed25519"}}`,
+			text:       true,
+			verifyFunc: verifyKey("ed25519", func(k interface{}) bool { _, ok := k.(ed25519.PrivateKey); return ok }),
+		},
+	})
+}
+
+func TestGenSelfSignedCert(t *testing.T) {
+	out := render(t, nil, `{{$c := gen_self_signed_cert "localhost" (list "127.0.0.1") (list "localhost" "example.local") 30 (gen_private_key "ecdsa")}}{{$c.Cert}}|{{$c.Key}}`, nil)
+	parts := strings.Split(out, "|")
+	if _, err := tls.X509KeyPair([]byte(parts[0]), []byte(parts[1])); err != nil {
+		t.Fatalf("got err=%v, want key pair", err)
+	}
+	cert := parseCert(t, parts[0])
+	if cert.Subject.CommonName != "localhost" || len(cert.IPAddresses) != 1 || len(cert.DNSNames) != 2 || cert.IsCA {
+		t.Errorf("got cn=%s, ips=%v, dns=%v, ca=%v", cert.Subject.CommonName, cert.IPAddresses, cert.DNSNames, cert.IsCA)
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+		t.Errorf("got err=%v, want self-signed certificate", err)
+	}
+	if d := cert.NotAfter.Sub(cert.NotBefore).Hours(); d != 30*24 {
+		t.Errorf("got validity=%vh, want 720h", d)
+	}
+}
+
+func TestGenSignedCert(t *testing.T) {
+	out := render(t, nil, `{{$ca := gen_ca "test-ca" 365 (gen_private_key "ed25519")}}{{$c := gen_signed_cert "server" nil (list "server.local") 30 $ca}}{{$ca.Cert}}|{{$c.Cert}}|{{$c.Key}}`, nil)
+	parts := strings.Split(out, "|")
+	ca := parseCert(t, parts[0])
+	if !ca.IsCA || ca.Subject.CommonName != "test-ca" {
+		t.Errorf("got ca=%v, cn=%s, want CA test-ca", ca.IsCA, ca.Subject.CommonName)
+	}
+	if _, err := tls.X509KeyPair([]byte(parts[1]), []byte(parts[2])); err != nil {
+		t.Fatalf("got err=%v, want key pair", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	cert := parseCert(t, parts[1])
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: pool, DNSName: "server.local"}); err != nil {
+		t.Errorf("got err=%v, want certificate signed by the CA", err)
+	}
+}
+
+func TestPEM(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "pem_encode",
+			template: `{{pem_encode "MESSAGE" "hello"}}`,
+			output:   "-----BEGIN MESSAGE-----\naGVsbG8=\n-----END MESSAGE-----\n",
+		},
+		{
+			name:     "pem_decode",
+			template: `{{$b := pem_decode .}}{{$b.Type}} {{printf "%s" $b.Bytes}}`,
+			data:     "-----BEGIN MESSAGE-----\naGVsbG8=\n-----END MESSAGE-----\n",
+			output:   "MESSAGE hello",
+		},
+	})
+}
+
+func TestCertError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "unsupported key",
+			template: `{{gen_private_key "dsa"}}`,
+			err:      `unsupported key algorithm: "dsa"`,
+		},
+		{
+			name:     "invalid days",
+			template: `{{gen_ca "ca" 0}}`,
+			err:      "invalid number of days: 0",
+		},
+		{
+			name:     "invalid key",
+			template: `{{gen_ca "ca" 1 "not a key"}}`,
+			err:      "invalid PEM data",
+		},
+		{
+			name:     "invalid ip",
+			template: `{{gen_self_signed_cert "x" (list "not an ip") nil 1}}`,
+			err:      `invalid IP address: "not an ip"`,
+		},
+		{
+			name:     "invalid pem",
+			template: `{{pem_decode "x"}}`,
+			err:      "invalid PEM data",
+		},
+	})
+}
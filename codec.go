@@ -0,0 +1,88 @@
+package template
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"mime/quotedprintable"
+	"net/url"
+	"strings"
+)
+
+// CodecFuncMap return binary to text codec func map.
+func CodecFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"b64enc":         encodeFunc(base64.StdEncoding.EncodeToString),
+		"b64dec":         decodeFunc(base64.StdEncoding.DecodeString),
+		"b64enc_url":     encodeFunc(base64.URLEncoding.EncodeToString),
+		"b64dec_url":     decodeFunc(base64.URLEncoding.DecodeString),
+		"b64enc_raw":     encodeFunc(base64.RawStdEncoding.EncodeToString),
+		"b64dec_raw":     decodeFunc(base64.RawStdEncoding.DecodeString),
+		"b64enc_raw_url": encodeFunc(base64.RawURLEncoding.EncodeToString),
+		"b64dec_raw_url": decodeFunc(base64.RawURLEncoding.DecodeString),
+		"b32enc":         encodeFunc(base32.StdEncoding.EncodeToString),
+		"b32dec":         decodeFunc(base32.StdEncoding.DecodeString),
+		"hex_encode":     encodeFunc(hex.EncodeToString),
+		"hex_decode":     decodeFunc(hex.DecodeString),
+		"urlquery":       URLQuery,
+		"urlunquery":     url.QueryUnescape,
+		"path_escape":    url.PathEscape,
+		"path_unescape":  url.PathUnescape,
+		"qp_encode":      encodeFunc(QuotedPrintableEncode),
+		"qp_decode":      decodeFunc(QuotedPrintableDecode),
+	}
+}
+
+// URLQuery return the escaped value of the textual representation of its arguments
+// in a form suitable for embedding in a URL query.
+// It is compatible with the urlquery builtin of text/template.
+func URLQuery(values ...interface{}) string {
+	return url.QueryEscape(evalArgs(values))
+}
+
+// QuotedPrintableEncode return the quoted-printable encoding of b, as defined in RFC 2045.
+func QuotedPrintableEncode(b []byte) string {
+	buf := bytes.Buffer{}
+	w := quotedprintable.NewWriter(&buf)
+	// writing to a bytes.Buffer never fails.
+	_, _ = w.Write(b)
+	_ = w.Close()
+	return buf.String()
+}
+
+// QuotedPrintableDecode return the bytes represented by the quoted-printable string s.
+func QuotedPrintableDecode(s string) ([]byte, error) {
+	return ioutil.ReadAll(quotedprintable.NewReader(strings.NewReader(s)))
+}
+
+// encodeFunc return a func which encodes the bytes of a string or a byte slice.
+func encodeFunc(f func([]byte) string) func(v interface{}) string {
+	return func(v interface{}) string {
+		return f(toBytes(v))
+	}
+}
+
+// decodeFunc return a func which decodes a string and return the result as string.
+func decodeFunc(f func(string) ([]byte, error)) func(s string) (string, error) {
+	return func(s string) (string, error) {
+		b, err := f(s)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+// toBytes return the bytes of a string or a byte slice,
+// or of the textual representation of other values.
+func toBytes(v interface{}) []byte {
+	switch v := v.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return []byte(evalArgs([]interface{}{v}))
+}
@@ -0,0 +1,104 @@
+package template_test
+
+import "testing"
+
+func TestCodec(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "b64enc",
+			template: `{{b64enc .}}`,
+			data:     "hello?>",
+			output:   "aGVsbG8/Pg==",
+		},
+		{
+			name:     "b64enc bytes",
+			template: `{{b64enc .}}`,
+			data:     []byte("hello"),
+			output:   "aGVsbG8=",
+		},
+		{
+			name:     "b64dec",
+			template: `{{b64dec "aGVsbG8/Pg=="}}`,
+			output:   "hello?&gt;",
+		},
+		{
+			name:     "b64enc url",
+			template: `{{b64enc_url .}}`,
+			data:     "hello?>",
+			output:   "aGVsbG8_Pg==",
+		},
+		{
+			name:     "b64dec url",
+			template: `{{b64dec_url "aGVsbG8_Pg=="}}`,
+			output:   "hello?&gt;",
+		},
+		{
+			name:     "b64enc raw",
+			template: `{{b64enc_raw .}}`,
+			data:     "hello?>",
+			output:   "aGVsbG8/Pg",
+		},
+		{
+			name:     "b64 raw url round trip",
+			template: `{{b64enc_raw_url .|b64dec_raw_url}}`,
+			data:     "hello?>",
+			output:   "hello?&gt;",
+		},
+		{
+			name:     "b32enc",
+			template: `{{b32enc .}}`,
+			data:     "hello",
+			output:   "NBSWY3DP",
+		},
+		{
+			name:     "b32dec",
+			template: `{{b32dec "NBSWY3DP"}}`,
+			output:   "hello",
+		},
+		{
+			name:     "hex",
+			template: `{{hex_encode .}} {{hex_encode .|hex_decode}}`,
+			data:     "hi",
+			output:   "6869 hi",
+		},
+		{
+			name:     "hex number",
+			template: `{{hex_encode .}}`,
+			data:     12,
+			output:   "3132",
+		},
+		{
+			name:     "urlquery",
+			template: `{{urlquery .}}`,
+			data:     "a b&c",
+			output:   "a&#43;b%26c",
+		},
+		{
+			name:     "urlunquery",
+			template: `{{urlunquery "a+b%26c"}}`,
+			output:   "a b&amp;c",
+		},
+		{
+			name:     "path escape",
+			template: `{{path_escape .}}`,
+			data:     "a b/c",
+			output:   "a%20b%2Fc",
+		},
+		{
+			name:     "path unescape",
+			template: `{{path_unescape "a%20b%2Fc"}}`,
+			output:   "a b/c",
+		},
+		{
+			name:     "quoted printable",
+			template: `{{qp_encode .}}`,
+			data:     "Tiếng Việt = ok",
+			output:   "Ti=E1=BA=BFng Vi=E1=BB=87t =3D ok",
+		},
+		{
+			name:     "quoted printable decode",
+			template: `{{qp_decode "Ti=E1=BA=BFng Vi=E1=BB=87t =3D ok"}}`,
+			output:   "Tiếng Việt = ok",
+		},
+	})
+}
@@ -0,0 +1,474 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	errNotCollection = errors.New("value must be a slice or array")
+)
+
+// CollectionFuncMap return collection func map.
+func CollectionFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"list":      List,
+		"append":    Append,
+		"prepend":   Prepend,
+		"first":     First,
+		"last":      Last,
+		"rest":      Rest,
+		"initial":   Initial,
+		"reverse":   Reverse,
+		"uniq":      Uniq,
+		"sort":      Sort,
+		"sub_list":  SubList,
+		"compact":   Compact,
+		"pluck":     Pluck,
+		"group_by":  GroupBy,
+		"index_by":  IndexBy,
+		"sort_by":   SortBy,
+		"where":     Where,
+		"where_not": WhereNot,
+	}
+}
+
+// List return a list of the given values.
+func List(v ...interface{}) []interface{} {
+	return v
+}
+
+// Append return a new list with the values added to the end of the collection.
+// The type of the collection is kept if all the values are assignable to its element type.
+func Append(collection interface{}, values ...interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	return concat(v, reflect.ValueOf(values), false).Interface(), nil
+}
+
+// Prepend return a new list with the values added to the beginning of the collection.
+// The type of the collection is kept if all the values are assignable to its element type.
+func Prepend(collection interface{}, values ...interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	return concat(v, reflect.ValueOf(values), true).Interface(), nil
+}
+
+// First return the first element of the collection, or nil if it is empty.
+func First(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil || v.Len() == 0 {
+		return nil, err
+	}
+	return v.Index(0).Interface(), nil
+}
+
+// Last return the last element of the collection, or nil if it is empty.
+func Last(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil || v.Len() == 0 {
+		return nil, err
+	}
+	return v.Index(v.Len() - 1).Interface(), nil
+}
+
+// Rest return all elements of the collection except the first one.
+func Rest(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	if v.Len() == 0 {
+		return v.Interface(), nil
+	}
+	return copySlice(v.Slice(1, v.Len())).Interface(), nil
+}
+
+// Initial return all elements of the collection except the last one.
+func Initial(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	if v.Len() == 0 {
+		return v.Interface(), nil
+	}
+	return copySlice(v.Slice(0, v.Len()-1)).Interface(), nil
+}
+
+// Reverse return a new list with the elements of the collection in reverse order.
+func Reverse(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	l := v.Len()
+	rs := reflect.MakeSlice(v.Type(), l, l)
+	for i := 0; i < l; i++ {
+		rs.Index(i).Set(v.Index(l - 1 - i))
+	}
+	return rs.Interface(), nil
+}
+
+// Uniq return a new list with the duplicated elements of the collection removed.
+// The first occurrence of each element is kept.
+func Uniq(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		dup := false
+		for j := 0; j < rs.Len(); j++ {
+			if same(rs.Index(j), v.Index(i)) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			rs = reflect.Append(rs, v.Index(i))
+		}
+	}
+	return rs.Interface(), nil
+}
+
+// Sort return a new list with the elements of the collection sorted in natural order.
+// Elements must be all numbers, which can be mixed integers and floats, or all strings.
+func Sort(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := copySlice(v)
+	sort.SliceStable(rs.Interface(), func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		ok, e := less(rs.Index(i), rs.Index(j))
+		if e != nil {
+			err = e
+		}
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs.Interface(), nil
+}
+
+// SubList return a part of the collection from start (inclusive) to the optional end (exclusive).
+// Negative indexes count from the end of the collection and out of range indexes are clamped,
+// unlike the builtin slice which fails on them. Strings are sliced by bytes.
+func SubList(collection interface{}, indexes ...int) (interface{}, error) {
+	v, isNil := indirect(reflect.ValueOf(collection))
+	if isNil {
+		return nil, errNotCollection
+	}
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array:
+	default:
+		return nil, errNotCollection
+	}
+	if len(indexes) > 2 {
+		return nil, errors.New("too many slice indexes")
+	}
+	l := v.Len()
+	start, end := 0, l
+	if len(indexes) > 0 {
+		start = clampIndex(indexes[0], l)
+	}
+	if len(indexes) > 1 {
+		end = clampIndex(indexes[1], l)
+	}
+	if end < start {
+		end = start
+	}
+	if v.Kind() == reflect.String {
+		return v.String()[start:end], nil
+	}
+	if v.Kind() == reflect.Array {
+		v, _ = toSlice(v.Interface())
+	}
+	return v.Slice(start, end).Interface(), nil
+}
+
+// Compact return a new list with the empty elements (IsEmpty) of the collection removed.
+func Compact(collection interface{}) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		if IsTrue(v.Index(i).Interface()) {
+			rs = reflect.Append(rs, v.Index(i))
+		}
+	}
+	return rs.Interface(), nil
+}
+
+// Pluck return a list of the values at the given dotted path of all elements of the collection.
+// Elements can be structs, maps or pointers to them. Missing values are nil.
+func Pluck(collection interface{}, path string) ([]interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		rs = append(rs, valueOf(field(v.Index(i), path)))
+	}
+	return rs, nil
+}
+
+// GroupBy groups the elements of the collection by the string representation
+// of the value at the given dotted path.
+func GroupBy(collection interface{}, path string) (map[string][]interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := make(map[string][]interface{})
+	for i := 0; i < v.Len(); i++ {
+		key := fmt.Sprintf("%v", printableValue(field(v.Index(i), path)))
+		rs[key] = append(rs[key], v.Index(i).Interface())
+	}
+	return rs, nil
+}
+
+// IndexBy return a map of the elements of the collection indexed by the string representation
+// of the value at the given dotted path. The last element wins if the keys are duplicated.
+func IndexBy(collection interface{}, path string) (map[string]interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := make(map[string]interface{})
+	for i := 0; i < v.Len(); i++ {
+		key := fmt.Sprintf("%v", printableValue(field(v.Index(i), path)))
+		rs[key] = v.Index(i).Interface()
+	}
+	return rs, nil
+}
+
+// SortBy return a new list with the elements of the collection sorted by the values
+// at the given dotted paths. A key can be followed by "asc" or "desc", i.e: "Date desc".
+// Later keys are used when the values of the previous keys are equal. Nil values come first.
+func SortBy(collection interface{}, keys ...string) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	type sortKey struct {
+		path string
+		desc bool
+	}
+	sks := make([]sortKey, 0, len(keys))
+	for _, key := range keys {
+		f := strings.Fields(key)
+		switch {
+		case len(f) == 1:
+			sks = append(sks, sortKey{path: f[0]})
+		case len(f) == 2 && (strings.EqualFold(f[1], "asc") || strings.EqualFold(f[1], "desc")):
+			sks = append(sks, sortKey{path: f[0], desc: strings.EqualFold(f[1], "desc")})
+		default:
+			return nil, fmt.Errorf("invalid sort key: %q", key)
+		}
+	}
+	rs := copySlice(v)
+	sort.SliceStable(rs.Interface(), func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		for _, sk := range sks {
+			x, y := field(rs.Index(i), sk.path), field(rs.Index(j), sk.path)
+			if sk.desc {
+				x, y = y, x
+			}
+			less, e := ltNil(x, y)
+			if e != nil {
+				err = e
+				return false
+			}
+			if less {
+				return true
+			}
+			if greater, _ := ltNil(y, x); greater {
+				return false
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rs.Interface(), nil
+}
+
+// Where return a new list with the elements of the collection whose value at the given dotted path
+// equals one of the values. If no value is given, the elements whose value IsTrue are returned.
+func Where(collection interface{}, path string, values ...interface{}) (interface{}, error) {
+	return where(collection, path, values, true)
+}
+
+// WhereNot return a new list with the elements of the collection whose value at the given dotted path
+// doesn't equal any of the values. If no value is given, the elements whose value IsEmpty are returned.
+func WhereNot(collection interface{}, path string, values ...interface{}) (interface{}, error) {
+	return where(collection, path, values, false)
+}
+
+func where(collection interface{}, path string, values []interface{}, want bool) (interface{}, error) {
+	v, err := toSlice(collection)
+	if err != nil {
+		return nil, err
+	}
+	rs := reflect.MakeSlice(v.Type(), 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		f := field(v.Index(i), path)
+		match := false
+		if len(values) == 0 {
+			match = IsTrue(valueOf(f))
+		}
+		for _, val := range values {
+			if same(f, reflect.ValueOf(val)) {
+				match = true
+				break
+			}
+		}
+		if match == want {
+			rs = reflect.Append(rs, v.Index(i))
+		}
+	}
+	return rs.Interface(), nil
+}
+
+// ltNil evaluates the comparison a < b, in which nil is less than any other value.
+func ltNil(x, y reflect.Value) (bool, error) {
+	if !x.IsValid() || !y.IsValid() {
+		return !x.IsValid() && y.IsValid(), nil
+	}
+	return less(x, y)
+}
+
+// less evaluates the comparison x < y like lt, but also compares integers with floats.
+func less(x, y reflect.Value) (bool, error) {
+	ok, err := lt(x, y)
+	if err != errBadComparison || !isNumber(x) || !isNumber(y) {
+		return ok, err
+	}
+	a, err := toNumber(x.Interface())
+	if err != nil {
+		return false, err
+	}
+	b, err := toNumber(y.Interface())
+	if err != nil {
+		return false, err
+	}
+	return a.cmp(b) < 0, nil
+}
+
+// isNumber reports whether v holds an integer or a float.
+func isNumber(v reflect.Value) bool {
+	k, err := basicKind(indirectInterface(v))
+	return err == nil && (k == intKind || k == uintKind || k == floatKind)
+}
+
+// valueOf return the interface value of v, or nil if v is the zero reflect.Value.
+func valueOf(v reflect.Value) interface{} {
+	if !v.IsValid() || !v.CanInterface() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// toSlice return the slice value of the given collection.
+// Arrays are copied into a new slice of the same element type.
+func toSlice(collection interface{}) (reflect.Value, error) {
+	v, isNil := indirect(reflect.ValueOf(collection))
+	if isNil {
+		return zero, errNotCollection
+	}
+	switch v.Kind() {
+	case reflect.Slice:
+		return v, nil
+	case reflect.Array:
+		return copySlice(v), nil
+	}
+	return zero, errNotCollection
+}
+
+// copySlice return a new slice which holds a copy of the elements of the given slice or array.
+func copySlice(v reflect.Value) reflect.Value {
+	rs := reflect.MakeSlice(reflect.SliceOf(v.Type().Elem()), v.Len(), v.Len())
+	reflect.Copy(rs, v)
+	return rs
+}
+
+// concat return a new slice holding the elements of the collection and the values,
+// the values are put before the elements if front is true.
+// The type of the collection is kept if all the values are assignable to its element type.
+func concat(collection, values reflect.Value, front bool) reflect.Value {
+	typ := reflect.TypeOf([]interface{}{})
+	if assignableElems(values, collection.Type().Elem()) {
+		typ = collection.Type()
+	}
+	parts := []reflect.Value{collection, values}
+	if front {
+		parts[0], parts[1] = values, collection
+	}
+	rs := reflect.MakeSlice(typ, 0, collection.Len()+values.Len())
+	for _, v := range parts {
+		for i := 0; i < v.Len(); i++ {
+			e := indirectInterface(v.Index(i))
+			if !e.IsValid() {
+				e = reflect.Zero(typ.Elem())
+			}
+			rs = reflect.Append(rs, e)
+		}
+	}
+	return rs
+}
+
+func assignableElems(v reflect.Value, typ reflect.Type) bool {
+	for i := 0; i < v.Len(); i++ {
+		e := indirectInterface(v.Index(i))
+		if !e.IsValid() || !e.Type().AssignableTo(typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// same reports whether the two values are equal, using eq for basic kinds
+// and reflect.DeepEqual for the others.
+func same(x, y reflect.Value) bool {
+	if ok, err := eq(x, y); err == nil {
+		return ok
+	}
+	x, y = indirectInterface(x), indirectInterface(y)
+	if !x.IsValid() || !y.IsValid() {
+		return x.IsValid() == y.IsValid()
+	}
+	return reflect.DeepEqual(x.Interface(), y.Interface())
+}
+
+func clampIndex(i, l int) int {
+	if i < 0 {
+		i += l
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > l {
+		return l
+	}
+	return i
+}
@@ -0,0 +1,233 @@
+package template_test
+
+import "testing"
+
+func TestCollection(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "list",
+			template: `{{list 1 "2" 3.5}}`,
+			output:   "[1 2 3.5]",
+		},
+		{
+			name:     "append keep type",
+			template: `{{append . 3 4}} {{append . 3|printf "%T"}}`,
+			data:     []int{1, 2},
+			output:   "[1 2 3 4] []int",
+		},
+		{
+			name:     "append mixed type",
+			template: `{{append . "x" true}} {{append . "x"|printf "%T"}}`,
+			data:     []int{1},
+			output:   "[1 x true] []interface {}",
+		},
+		{
+			name:     "prepend",
+			template: `{{prepend . 0}} {{prepend . 0|printf "%T"}}`,
+			data:     [2]int{1, 2},
+			output:   "[0 1 2] []int",
+		},
+		{
+			name:     "first",
+			template: `{{.|first}}`,
+			data:     []string{"a", "b", "c"},
+			output:   "a",
+		},
+		{
+			name:     "first empty",
+			template: `{{.|first}}`,
+			data:     []string{},
+			output:   "",
+		},
+		{
+			name:     "last",
+			template: `{{.|last}}`,
+			data:     []string{"a", "b", "c"},
+			output:   "c",
+		},
+		{
+			name:     "rest",
+			template: `{{.|rest}}`,
+			data:     []string{"a", "b", "c"},
+			output:   "[b c]",
+		},
+		{
+			name:     "initial",
+			template: `{{.|initial}}`,
+			data:     []string{"a", "b", "c"},
+			output:   "[a b]",
+		},
+		{
+			name:     "reverse",
+			template: `{{.|reverse}}`,
+			data:     []int{1, 2, 3},
+			output:   "[3 2 1]",
+		},
+		{
+			name:     "uniq",
+			template: `{{.|uniq}}`,
+			data:     []interface{}{1, "1", 2, 1, []int{1}, []int{1}, true, true},
+			output:   "[1 1 2 [1] true]",
+		},
+		{
+			name:     "sort numbers",
+			template: `{{.|sort}}`,
+			data:     []float64{3, 1.5, 2},
+			output:   "[1.5 2 3]",
+		},
+		{
+			name:     "sort strings",
+			template: `{{list "b" "c" "a"|sort}}`,
+			output:   "[a b c]",
+		},
+		{
+			name:     "sort mixed numbers",
+			template: `{{sort (list 3 1 2.5 -1.5)}} {{sort .}}`,
+			data:     []interface{}{uint(3), 2.5, -1},
+			output:   "[-1.5 1 2.5 3] [-1 2.5 3]",
+		},
+		{
+			name:     "sort numbers and strings",
+			template: `{{sort (list 1 "a")}}`,
+			err:      "incompatible types for comparison",
+		},
+		{
+			name:     "sort does not modify input",
+			template: `{{$s := .|sort}}{{.}} {{$s}}`,
+			data:     []int{2, 1},
+			output:   "[2 1] [1 2]",
+		},
+		{
+			name:     "sub_list",
+			template: `{{sub_list . 1 3}}`,
+			data:     []int{1, 2, 3, 4},
+			output:   "[2 3]",
+		},
+		{
+			name:     "sub_list negative and clamp",
+			template: `{{sub_list . -2 10}}`,
+			data:     []int{1, 2, 3, 4},
+			output:   "[3 4]",
+		},
+		{
+			name:     "sub_list string",
+			template: `{{sub_list . 1}}`,
+			data:     "hello",
+			output:   "ello",
+		},
+		{
+			name:     "builtin slice",
+			template: `{{slice . 1 2 3}}`,
+			data:     []int{1, 2, 3, 4},
+			output:   "[2]",
+		},
+		{
+			name:     "compact",
+			template: `{{.|compact}}`,
+			data:     []interface{}{0, 1, "", "x", nil, false, true},
+			output:   "[1 x true]",
+		},
+	})
+}
+
+func TestCollectionFields(t *testing.T) {
+	type customer struct {
+		Name string
+	}
+	type invoice struct {
+		ID       int
+		Customer *customer
+		Total    float64
+		Paid     bool
+	}
+	jack, jill := &customer{Name: "jack"}, &customer{Name: "jill"}
+	invoices := []invoice{
+		{ID: 1, Customer: jack, Total: 10, Paid: true},
+		{ID: 2, Customer: jill, Total: 5},
+		{ID: 3, Customer: jack, Total: 5, Paid: true},
+		{ID: 4, Total: 1},
+	}
+	testIt(t, []testCase{
+		{
+			name:     "pluck struct",
+			template: `{{pluck . "ID"}}`,
+			data:     invoices,
+			output:   "[1 2 3 4]",
+		},
+		{
+			name:     "pluck dotted path",
+			template: `{{join "," (pluck . "Customer.Name")}}`,
+			data:     invoices,
+			output:   "jack,jill,jack,",
+		},
+		{
+			name:     "pluck map",
+			template: `{{pluck . "x.y"}}`,
+			data: []map[string]interface{}{
+				{"x": map[string]int{"y": 1}},
+				{"x": map[string]int{"y": 2}},
+			},
+			output: "[1 2]",
+		},
+		{
+			name:     "pluck promoted field through nil pointer",
+			template: `{{join "," (pluck . "Name")}}`,
+			data:     []struct{ *customer }{{jack}, {nil}},
+			output:   "jack,",
+		},
+		{
+			name:     "group by",
+			template: `{{range $k, $v := group_by . "Customer.Name"}}{{$k}}={{pluck $v "ID"}};{{end}}`,
+			data:     invoices,
+			output:   "=[4];jack=[1 3];jill=[2];",
+		},
+		{
+			name:     "index by",
+			template: `{{(index (index_by . "ID") "2").Customer.Name}}`,
+			data:     invoices,
+			output:   "jill",
+		},
+		{
+			name:     "sort by",
+			template: `{{pluck (sort_by . "Total") "ID"}}`,
+			data:     invoices,
+			output:   "[4 2 3 1]",
+		},
+		{
+			name:     "sort by multiple keys",
+			template: `{{pluck (sort_by . "Total desc" "ID DESC") "ID"}}`,
+			data:     invoices,
+			output:   "[1 3 2 4]",
+		},
+		{
+			name:     "sort by nil first",
+			template: `{{pluck (sort_by . "Customer.Name" "ID desc") "ID"}}`,
+			data:     invoices,
+			output:   "[4 3 1 2]",
+		},
+		{
+			name:     "where",
+			template: `{{pluck (where . "Customer.Name" "jack") "ID"}}`,
+			data:     invoices,
+			output:   "[1 3]",
+		},
+		{
+			name:     "where any value",
+			template: `{{pluck (where . "ID" 1 4) "ID"}}`,
+			data:     invoices,
+			output:   "[1 4]",
+		},
+		{
+			name:     "where true",
+			template: `{{pluck (where . "Paid") "ID"}}`,
+			data:     invoices,
+			output:   "[1 3]",
+		},
+		{
+			name:     "where not",
+			template: `{{pluck (where_not . "Customer.Name" "jack") "ID"}}`,
+			data:     invoices,
+			output:   "[2 4]",
+		},
+	})
+}
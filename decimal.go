@@ -0,0 +1,255 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Decimal is an arbitrary-precision decimal number.
+	// Its zero value is 0.
+	Decimal struct {
+		r *big.Rat
+	}
+
+	// RoundingMode specifies how a Decimal is rounded to a scale.
+	RoundingMode string
+)
+
+// Rounding modes.
+const (
+	// RoundHalfUp rounds to the nearest neighbor, ties away from zero.
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfDown rounds to the nearest neighbor, ties toward zero.
+	RoundHalfDown RoundingMode = "half_down"
+	// RoundHalfEven rounds to the nearest neighbor, ties to the even neighbor.
+	RoundHalfEven RoundingMode = "half_even"
+	// RoundUp rounds away from zero.
+	RoundUp RoundingMode = "up"
+	// RoundDown rounds toward zero.
+	RoundDown RoundingMode = "down"
+	// RoundCeil rounds toward positive infinity.
+	RoundCeil RoundingMode = "ceil"
+	// RoundFloor rounds toward negative infinity.
+	RoundFloor RoundingMode = "floor"
+)
+
+const (
+	// maxDecimalScale is the number of fraction digits used to print
+	// a Decimal which doesn't have a finite decimal representation.
+	maxDecimalScale = 34
+)
+
+var (
+	errDivisionByZero = errors.New("division by zero")
+)
+
+// ParseDecimal return the Decimal of the given value.
+// The value can be an int, uint, float, string, Decimal, *big.Int, *big.Float or *big.Rat.
+// Floats are converted using their shortest decimal representation, i.e: 0.1 is exactly 0.1.
+func ParseDecimal(v interface{}) (Decimal, error) {
+	switch val := v.(type) {
+	case Decimal:
+		return Decimal{r: val.rat()}, nil
+	case *Decimal:
+		if val != nil {
+			return Decimal{r: val.rat()}, nil
+		}
+	case *big.Rat:
+		if val != nil {
+			return Decimal{r: new(big.Rat).Set(val)}, nil
+		}
+	case *big.Float:
+		if val != nil && !val.IsInf() {
+			r, _ := val.Rat(nil)
+			return Decimal{r: r}, nil
+		}
+	case *big.Int:
+		if val != nil {
+			return Decimal{r: new(big.Rat).SetInt(val)}, nil
+		}
+	}
+	rv := indirectInterface(reflect.ValueOf(v))
+	k, err := basicKind(rv)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("invalid decimal: %v", v)
+	}
+	switch k {
+	case intKind:
+		return Decimal{r: new(big.Rat).SetInt64(rv.Int())}, nil
+	case uintKind:
+		return Decimal{r: new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint()))}, nil
+	case floatKind:
+		return parseDecimalString(strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()))
+	case stringKind:
+		return parseDecimalString(strings.TrimSpace(rv.String()))
+	}
+	return Decimal{}, fmt.Errorf("invalid decimal: %v", v)
+}
+
+func parseDecimalString(s string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %q", s)
+	}
+	return Decimal{r: r}, nil
+}
+
+// Rat return the value of d as a *big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return d.rat()
+}
+
+// Round return d rounded to the given number of fraction digits using the given mode.
+func (d Decimal) Round(scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 {
+		return Decimal{}, fmt.Errorf("invalid scale: %d", scale)
+	}
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)
+	// q, m = num*10^scale / denom
+	r := d.rat()
+	num := new(big.Int).Mul(r.Num(), exp)
+	q, m := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		// compare 2*|m| with denom to know where we are relative to the half.
+		m2 := new(big.Int).Abs(m)
+		half := m2.Lsh(m2, 1).Cmp(r.Denom())
+		neg := r.Sign() < 0
+		away := false
+		switch mode {
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfDown:
+			away = half > 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		case RoundUp:
+			away = true
+		case RoundDown:
+			away = false
+		case RoundCeil:
+			away = !neg
+		case RoundFloor:
+			away = neg
+		default:
+			return Decimal{}, fmt.Errorf("invalid rounding mode: %q", mode)
+		}
+		if away && neg {
+			q.Sub(q, big.NewInt(1))
+		} else if away {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return Decimal{r: new(big.Rat).SetFrac(q, exp)}, nil
+}
+
+// String return the exact decimal representation of d. Numbers without a finite
+// decimal representation, i.e: 1/3, are rounded to 34 fraction digits.
+func (d Decimal) String() string {
+	r := d.rat()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	// a fraction has a finite decimal representation if its denominator has
+	// only 2 and 5 as prime factors; the scale is the larger exponent of them.
+	denom := new(big.Int).Set(r.Denom())
+	scale := 0
+	for _, p := range []int64{2, 5} {
+		n := 0
+		bp := big.NewInt(p)
+		m := new(big.Int)
+		for {
+			q, rem := new(big.Int).QuoRem(denom, bp, m)
+			if rem.Sign() != 0 {
+				break
+			}
+			denom = q
+			n++
+		}
+		if n > scale {
+			scale = n
+		}
+	}
+	if denom.Cmp(big.NewInt(1)) == 0 {
+		return r.FloatString(scale)
+	}
+	s := r.FloatString(maxDecimalScale)
+	// drop the sign of a tiny negative number rounded to zero.
+	if strings.Trim(s, "-0.") == "" {
+		s = strings.TrimPrefix(s, "-")
+	}
+	return s
+}
+
+func (d Decimal) rat() *big.Rat {
+	if d.r == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(d.r)
+}
+
+// DecAdd return the exact sum of the values.
+func DecAdd(values ...interface{}) (Decimal, error) {
+	return decCal(add, values)
+}
+
+// DecSub return the exact result of subtracting the rest of the values from the first one.
+func DecSub(values ...interface{}) (Decimal, error) {
+	return decCal(sub, values)
+}
+
+// DecMul return the exact product of the values.
+func DecMul(values ...interface{}) (Decimal, error) {
+	return decCal(mul, values)
+}
+
+// DecDiv divides the first value by the rest of the values and return the result
+// rounded to the given number of fraction digits using the given rounding mode.
+func DecDiv(scale int, mode string, values ...interface{}) (Decimal, error) {
+	d, err := decCal(div, values)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return d.Round(scale, RoundingMode(mode))
+}
+
+// DecRound return the value rounded to the given number of fraction digits using the given rounding mode.
+func DecRound(scale int, mode string, v interface{}) (Decimal, error) {
+	d, err := ParseDecimal(v)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return d.Round(scale, RoundingMode(mode))
+}
+
+func decCal(op operator, values []interface{}) (Decimal, error) {
+	r := new(big.Rat)
+	for i, v := range values {
+		d, err := ParseDecimal(v)
+		if err != nil {
+			return Decimal{}, err
+		}
+		if i == 0 {
+			r = d.r
+			continue
+		}
+		switch op {
+		case add:
+			r.Add(r, d.r)
+		case sub:
+			r.Sub(r, d.r)
+		case mul:
+			r.Mul(r, d.r)
+		case div:
+			if d.r.Sign() == 0 {
+				return Decimal{}, errDivisionByZero
+			}
+			r.Quo(r, d.r)
+		}
+	}
+	return Decimal{r: r}, nil
+}
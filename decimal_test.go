@@ -0,0 +1,118 @@
+package template_test
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimal(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "dec_add float",
+			template: `{{dec_add 0.1 0.2}}`,
+			output:   "0.3",
+		},
+		{
+			name:     "dec_add mixed",
+			template: `{{dec_add 1 "2.25" 0.5}}`,
+			output:   "3.75",
+		},
+		{
+			name:     "dec_sub",
+			template: `{{dec_sub "10.00" 0.01 3}}`,
+			output:   "6.99",
+		},
+		{
+			name:     "dec_mul",
+			template: `{{dec_mul "19.99" 3}}`,
+			output:   "59.97",
+		},
+		{
+			name:     "dec chained",
+			template: `{{dec_mul (dec_add 0.1 0.2) 3}}`,
+			output:   "0.9",
+		},
+		{
+			name:     "dec big values",
+			template: `{{dec_add "12345678901234567890.1" "0.0000000001"}}`,
+			output:   "12345678901234567890.1000000001",
+		},
+		{
+			name:     "dec finite beyond 34 digits",
+			template: `{{dec_mul "0.0000000000000000001" "0.0000000000000000001"}} {{dec "-1e-40"}}`,
+			output:   "0.00000000000000000000000000000000000001 -0.0000000000000000000000000000000000000001",
+		},
+		{
+			name:     "dec_div scale above 34",
+			template: `{{dec_div 40 "half_up" 1 3}}`,
+			output:   "0.3333333333333333333333333333333333333333",
+		},
+		{
+			name:     "dec not terminating",
+			template: `{{dec .}}`,
+			data:     big.NewRat(-1, 9),
+			output:   "-0.1111111111111111111111111111111111",
+		},
+		{
+			name:     "dec not terminating rounded to zero",
+			template: `{{dec .}}`,
+			data:     new(big.Rat).SetFrac(big.NewInt(-1), new(big.Int).Mul(big.NewInt(3), new(big.Int).Exp(big.NewInt(10), big.NewInt(40), nil))),
+			output:   "0.0000000000000000000000000000000000",
+		},
+		{
+			name:     "dec_div half_up",
+			template: `{{dec_div 2 "half_up" 10 3}}`,
+			output:   "3.33",
+		},
+		{
+			name:     "dec_div up",
+			template: `{{dec_div 2 "up" 10 3}}`,
+			output:   "3.34",
+		},
+		{
+			name:     "dec_round half_up",
+			template: `{{dec_round 1 "half_up" "2.25"}}`,
+			output:   "2.3",
+		},
+		{
+			name:     "dec_round half_even",
+			template: `{{dec_round 1 "half_even" "2.25"}}`,
+			output:   "2.2",
+		},
+		{
+			name:     "dec_round half_down",
+			template: `{{dec_round 1 "half_down" "-2.25"}}`,
+			output:   "-2.2",
+		},
+		{
+			name:     "dec_round floor negative",
+			template: `{{dec_round 0 "floor" "-2.1"}}`,
+			output:   "-3",
+		},
+		{
+			name:     "dec_round ceil",
+			template: `{{dec_round 0 "ceil" "2.1"}}`,
+			output:   "3",
+		},
+		{
+			name:     "dec_round down",
+			template: `{{dec_round 2 "down" "-2.129"}}`,
+			output:   "-2.12",
+		},
+		{
+			name:     "dec big types",
+			template: `{{dec_add .X .Y}}`,
+			data: map[string]interface{}{
+				"X": big.NewRat(1, 4),
+				"Y": big.NewFloat(0.5),
+			},
+			output: "0.75",
+		},
+		{
+			name:     "dec non terminating",
+			template: `{{dec .}}`,
+			data:     big.NewRat(1, 3),
+			output:   "0.3333333333333333333333333333333333",
+		},
+	})
+}
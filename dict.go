@@ -0,0 +1,257 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+var (
+	errNotMap = errors.New("value must be a map")
+)
+
+// DictFuncMap return dictionary func map.
+func DictFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"dict_set":        DictSet,
+		"dict_unset":      DictUnset,
+		"dict_get":        DictGet,
+		"keys":            Keys,
+		"values":          Values,
+		"pick":            Pick,
+		"omit":            Omit,
+		"merge":           Merge,
+		"merge_keep":      MergeKeep,
+		"merge_deep":      MergeDeep,
+		"merge_deep_keep": MergeDeepKeep,
+		"dig":             Dig,
+	}
+}
+
+// DictSet sets the value of the key in the map and return the map.
+func DictSet(m interface{}, key string, value interface{}) (interface{}, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	k, ok := mapKey(v.Type().Key(), key)
+	if !ok {
+		return nil, fmt.Errorf("invalid key %q for %s", key, v.Type())
+	}
+	val := reflect.ValueOf(value)
+	if !val.IsValid() {
+		val = reflect.Zero(v.Type().Elem())
+	}
+	if !val.Type().AssignableTo(v.Type().Elem()) {
+		return nil, fmt.Errorf("invalid value type %s for %s", val.Type(), v.Type())
+	}
+	v.SetMapIndex(k, val)
+	return m, nil
+}
+
+// DictUnset deletes the key from the map and return the map.
+func DictUnset(m interface{}, key string) (interface{}, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := mapKey(v.Type().Key(), key); ok {
+		v.SetMapIndex(k, zero)
+	}
+	return m, nil
+}
+
+// DictGet return the value of the key in the map.
+// If the key doesn't exist, the optional default value or nil is returned.
+func DictGet(m interface{}, key string, df ...interface{}) (interface{}, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	if k, ok := mapKey(v.Type().Key(), key); ok {
+		if val := v.MapIndex(k); val.IsValid() {
+			return val.Interface(), nil
+		}
+	}
+	return defaultOf(df), nil
+}
+
+// Keys return the string representation of the keys of the map, in the natural order of the keys:
+// numbers are sorted numerically and the other keys by their string representation.
+func Keys(m interface{}) ([]string, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, v.Len())
+	for _, k := range sortedKeys(v) {
+		keys = append(keys, fmt.Sprintf("%v", k.Interface()))
+	}
+	return keys, nil
+}
+
+// Values return the values of the map, ordered by their keys like Keys.
+func Values(m interface{}) ([]interface{}, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	rs := make([]interface{}, 0, v.Len())
+	for _, k := range sortedKeys(v) {
+		rs = append(rs, valueOf(indirectInterface(v.MapIndex(k))))
+	}
+	return rs, nil
+}
+
+// Pick return a new map holding only the given keys of the map.
+func Pick(m interface{}, keys ...string) (map[string]interface{}, error) {
+	d, err := toDict(m, false)
+	if err != nil {
+		return nil, err
+	}
+	rs := make(map[string]interface{})
+	for _, k := range keys {
+		if val, ok := d[k]; ok {
+			rs[k] = val
+		}
+	}
+	return rs, nil
+}
+
+// Omit return a new map holding all keys of the map except the given keys.
+func Omit(m interface{}, keys ...string) (map[string]interface{}, error) {
+	d, err := toDict(m, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		delete(d, k)
+	}
+	return d, nil
+}
+
+// Merge return a new map holding the keys of all the maps.
+// The values of the later maps override the values of the former ones.
+func Merge(maps ...interface{}) (map[string]interface{}, error) {
+	return merge(false, true, maps)
+}
+
+// MergeKeep return a new map holding the keys of all the maps.
+// The values of the former maps are kept.
+func MergeKeep(maps ...interface{}) (map[string]interface{}, error) {
+	return merge(false, false, maps)
+}
+
+// MergeDeep return a new map holding the keys of all the maps, nested maps are merged recursively.
+// The values of the later maps override the values of the former ones.
+func MergeDeep(maps ...interface{}) (map[string]interface{}, error) {
+	return merge(true, true, maps)
+}
+
+// MergeDeepKeep return a new map holding the keys of all the maps, nested maps are merged recursively.
+// The values of the former maps are kept.
+func MergeDeepKeep(maps ...interface{}) (map[string]interface{}, error) {
+	return merge(true, false, maps)
+}
+
+// Dig return the value at the given dotted path, i.e: "user.addresses.0.city",
+// in nested maps, structs, slices and arrays.
+// If the path doesn't exist, the optional default value or nil is returned.
+func Dig(v interface{}, path string, df ...interface{}) interface{} {
+	rs := field(reflect.ValueOf(v), path)
+	if !rs.IsValid() || !rs.CanInterface() {
+		return defaultOf(df)
+	}
+	return rs.Interface()
+}
+
+func merge(deep bool, override bool, maps []interface{}) (map[string]interface{}, error) {
+	rs := make(map[string]interface{})
+	for _, m := range maps {
+		d, err := toDict(m, deep)
+		if err != nil {
+			return nil, err
+		}
+		mergeInto(rs, d, deep, override)
+	}
+	return rs, nil
+}
+
+func mergeInto(dst, src map[string]interface{}, deep bool, override bool) {
+	for k, sv := range src {
+		dv, ok := dst[k]
+		if !ok {
+			dst[k] = sv
+			continue
+		}
+		dm, dok := dv.(map[string]interface{})
+		sm, sok := sv.(map[string]interface{})
+		if deep && dok && sok {
+			// copy to avoid modifying the nested maps of the inputs.
+			nm := make(map[string]interface{}, len(dm))
+			mergeInto(nm, dm, deep, true)
+			mergeInto(nm, sm, deep, override)
+			dst[k] = nm
+			continue
+		}
+		if override {
+			dst[k] = sv
+		}
+	}
+}
+
+// toMap return the map value of m.
+func toMap(m interface{}) (reflect.Value, error) {
+	v, isNil := indirect(reflect.ValueOf(m))
+	if isNil || v.Kind() != reflect.Map {
+		return zero, errNotMap
+	}
+	return v, nil
+}
+
+// sortedKeys return the keys of the map v, numbers sorted numerically first
+// then the other keys sorted by their string representation.
+func sortedKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		x, y := keys[i], keys[j]
+		if isNumber(x) && isNumber(y) {
+			ok, _ := less(x, y)
+			return ok
+		}
+		if isNumber(x) != isNumber(y) {
+			return isNumber(x)
+		}
+		return fmt.Sprintf("%v", x.Interface()) < fmt.Sprintf("%v", y.Interface())
+	})
+	return keys
+}
+
+// toDict return a copy of m as a map of string -> interface.
+// If deep is true, nested maps are converted as well.
+func toDict(m interface{}, deep bool) (map[string]interface{}, error) {
+	v, err := toMap(m)
+	if err != nil {
+		return nil, err
+	}
+	rs := make(map[string]interface{}, v.Len())
+	r := v.MapRange()
+	for r.Next() {
+		val := valueOf(indirectInterface(r.Value()))
+		if deep {
+			if nv, _ := indirect(reflect.ValueOf(val)); nv.Kind() == reflect.Map {
+				val, _ = toDict(val, deep)
+			}
+		}
+		rs[fmt.Sprintf("%v", r.Key().Interface())] = val
+	}
+	return rs, nil
+}
+
+func defaultOf(df []interface{}) interface{} {
+	if len(df) > 0 {
+		return df[0]
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package template_test
+
+import "testing"
+
+func TestDict(t *testing.T) {
+	type user struct {
+		Name      string
+		Addresses []map[string]string
+	}
+	testIt(t, []testCase{
+		{
+			name:     "dict_set",
+			template: `{{$m := map "a" 1}}{{$_ := dict_set $m "b" 2}}{{$m}}`,
+			output:   "map[a:1 b:2]",
+		},
+		{
+			name:     "dict_set typed map",
+			template: `{{dict_set . "b" "y"}}`,
+			data:     map[string]string{"a": "x"},
+			output:   "map[a:x b:y]",
+		},
+		{
+			name:     "dict_unset",
+			template: `{{dict_unset (map "a" 1 "b" 2) "a"}}`,
+			output:   "map[b:2]",
+		},
+		{
+			name:     "dict_get",
+			template: `{{dict_get . "a"}}`,
+			data:     map[string]int{"a": 1},
+			output:   "1",
+		},
+		{
+			name:     "dict_get default",
+			template: `{{dict_get . "b" "none"}}`,
+			data:     map[string]int{"a": 1},
+			output:   "none",
+		},
+		{
+			name:     "dict_get int key",
+			template: `{{dict_get . "2"}}`,
+			data:     map[int]string{2: "x"},
+			output:   "x",
+		},
+		{
+			name:     "keys",
+			template: `{{keys .}}`,
+			data:     map[string]int{"c": 1, "a": 2, "b": 3},
+			output:   "[a b c]",
+		},
+		{
+			name:     "values",
+			template: `{{values .}}`,
+			data:     map[string]int{"c": 1, "a": 2, "b": 3},
+			output:   "[2 3 1]",
+		},
+		{
+			name:     "keys and values numeric",
+			template: `{{keys .}} {{values .}}`,
+			data:     map[int]string{2: "b", 10: "c", -1: "a"},
+			output:   "[-1 2 10] [a b c]",
+		},
+		{
+			name:     "keys mixed",
+			template: `{{keys .}}`,
+			data:     map[interface{}]int{"b": 1, 10: 2, 2.5: 3, "a": 4},
+			output:   "[2.5 10 a b]",
+		},
+		{
+			name:     "pick",
+			template: `{{pick . "a" "c" "x"}}`,
+			data:     map[string]int{"c": 1, "a": 2, "b": 3},
+			output:   "map[a:2 c:1]",
+		},
+		{
+			name:     "omit",
+			template: `{{omit . "a" "c"}}`,
+			data:     map[string]int{"c": 1, "a": 2, "b": 3},
+			output:   "map[b:3]",
+		},
+		{
+			name:     "merge",
+			template: `{{merge (map "a" 1 "b" 2) (map "b" 3 "c" 4)}}`,
+			output:   "map[a:1 b:3 c:4]",
+		},
+		{
+			name:     "merge keep",
+			template: `{{merge_keep (map "a" 1 "b" 2) (map "b" 3 "c" 4)}}`,
+			output:   "map[a:1 b:2 c:4]",
+		},
+		{
+			name:     "merge shallow replaces nested map",
+			template: `{{merge (map "x" (map "a" 1 "b" 2)) (map "x" (map "b" 3))}}`,
+			output:   "map[x:map[b:3]]",
+		},
+		{
+			name:     "merge deep",
+			template: `{{merge_deep (map "x" (map "a" 1 "b" 2)) (map "x" (map "b" 3 "c" 4))}}`,
+			output:   "map[x:map[a:1 b:3 c:4]]",
+		},
+		{
+			name:     "merge deep keep",
+			template: `{{merge_deep_keep (map "x" (map "a" 1 "b" 2)) (map "x" (map "b" 3 "c" 4))}}`,
+			output:   "map[x:map[a:1 b:2 c:4]]",
+		},
+		{
+			name:     "merge deep does not modify inputs",
+			template: `{{$a := map "x" (map "a" 1)}}{{$_ := merge_deep $a (map "x" (map "b" 2))}}{{$a}}`,
+			output:   "map[x:map[a:1]]",
+		},
+		{
+			name:     "dig",
+			template: `{{dig . "Addresses.1.city"}}`,
+			data: user{
+				Name:      "jack",
+				Addresses: []map[string]string{{"city": "hanoi"}, {"city": "hcm"}},
+			},
+			output: "hcm",
+		},
+		{
+			name:     "dig default",
+			template: `{{dig . "Addresses.2.city" "unknown"}}`,
+			data: &user{
+				Name:      "jack",
+				Addresses: []map[string]string{{"city": "hanoi"}},
+			},
+			output: "unknown",
+		},
+	})
+}
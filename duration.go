@@ -0,0 +1,276 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type (
+	// DurationOptions configures how a duration is formatted.
+	DurationOptions struct {
+		// LargestUnit is the largest unit used, i.e: "hours" formats 2 days as 48 hours.
+		// Default is days.
+		LargestUnit string
+		// SmallestUnit is the smallest unit used, i.e: "milliseconds" to show sub-second precision.
+		// Default is seconds.
+		SmallestUnit string
+		// MaxUnits is the maximum number of units used, i.e: 2 formats 1h2m3s as 1 hour 2 minutes.
+		// Zero means no limit.
+		MaxUnits int
+		// Short uses the short unit names, i.e: 1h 2m instead of 1 hour 2 minutes.
+		Short bool
+		// Round rounds the last unit half away from zero instead of truncating it.
+		Round bool
+		// Abs drops the sign of negative durations instead of prefixing them with -.
+		Abs bool
+		// Locale is the language of the unit names: en, vi, fr, de or es. Default is en.
+		Locale string
+	}
+)
+
+var (
+	// durationUnits are the units of a duration from the largest to the smallest.
+	durationUnits = []timeUnit{
+		{d: 7 * 24 * time.Hour, name: "weeks", short: "w"},
+		{d: 24 * time.Hour, name: "days", short: "d"},
+		{d: time.Hour, name: "hours", short: "h"},
+		{d: time.Minute, name: "minutes", short: "m"},
+		{d: time.Second, name: "seconds", short: "s"},
+		{d: time.Millisecond, name: "milliseconds", short: "ms"},
+		{d: time.Microsecond, name: "microseconds", short: "µs"},
+		{d: time.Nanosecond, name: "nanoseconds", short: "ns"},
+	}
+
+	// durationUnitNames maps the accepted unit names to their index in durationUnits.
+	durationUnitNames = map[string]int{
+		"w": 0, "wk": 0, "wks": 0, "week": 0, "weeks": 0,
+		"d": 1, "day": 1, "days": 1,
+		"h": 2, "hr": 2, "hrs": 2, "hour": 2, "hours": 2,
+		"m": 3, "min": 3, "mins": 3, "minute": 3, "minutes": 3,
+		"s": 4, "sec": 4, "secs": 4, "second": 4, "seconds": 4,
+		"ms": 5, "millisecond": 5, "milliseconds": 5,
+		"us": 6, "µs": 6, "μs": 6, "microsecond": 6, "microseconds": 6,
+		"ns": 7, "nanosecond": 7, "nanoseconds": 7,
+	}
+
+	errInvalidDuration = errors.New("invalid duration")
+)
+
+// FormatDuration return human readable string of the duration using hours,
+// minutes and seconds, i.e: "1 hour 2 minutes 3 seconds".
+// The duration can be a time.Duration, a string like "1h30m" or an integer of nanoseconds.
+func FormatDuration(v interface{}) string {
+	d, err := toDuration(v)
+	if err != nil {
+		return ""
+	}
+	rs, _ := FormatDurationWithOptions(d, DurationOptions{LargestUnit: "hours"})
+	return rs
+}
+
+// FormatDurationWithOptions return human readable string of the duration using the given options,
+// i.e: "2 days 3 hours" or "2d 3h" in short form.
+func FormatDurationWithOptions(d time.Duration, opts DurationOptions) (string, error) {
+	largest, err := durationUnitIndex(opts.LargestUnit, 1)
+	if err != nil {
+		return "", err
+	}
+	smallest, err := durationUnitIndex(opts.SmallestUnit, 4)
+	if err != nil {
+		return "", err
+	}
+	if largest > smallest {
+		return "", fmt.Errorf("largest unit %s is smaller than smallest unit %s", opts.LargestUnit, opts.SmallestUnit)
+	}
+	neg := d < 0
+	if neg {
+		d = -d
+		if d < 0 { // math.MinInt64
+			d = math.MaxInt64
+		}
+	}
+	// find the last unit to be shown, then truncate or round the duration to it.
+	last := smallest
+	if opts.MaxUnits > 0 {
+		first := smallest
+		for i := largest; i <= smallest; i++ {
+			if d >= durationUnits[i].d {
+				first = i
+				break
+			}
+		}
+		if first+opts.MaxUnits-1 < last {
+			last = first + opts.MaxUnits - 1
+		}
+	}
+	if opts.Round {
+		d = d.Round(durationUnits[last].d)
+	} else {
+		d = d.Truncate(durationUnits[last].d)
+	}
+	l := durationLocaleOf(opts.Locale)
+	parts := make([]string, 0)
+	for i := largest; i <= last; i++ {
+		u := durationUnits[i]
+		n := int64(d / u.d)
+		d -= time.Duration(n) * u.d
+		if n == 0 || (opts.MaxUnits > 0 && len(parts) >= opts.MaxUnits) {
+			continue
+		}
+		parts = append(parts, formatDurationUnit(l, i, n, opts.Short))
+	}
+	if len(parts) == 0 {
+		return formatDurationUnit(l, last, 0, opts.Short), nil
+	}
+	rs := strings.Join(parts, " ")
+	if neg && !opts.Abs {
+		rs = "-" + rs
+	}
+	return rs, nil
+}
+
+// ParseDuration parses a duration in Go format, i.e: "1h30m", or in words, i.e: "2 days",
+// "1 week, 2 days and 3.5 hours". Accepted units are weeks, days, hours, minutes, seconds,
+// milliseconds, microseconds and nanoseconds, in their long or short form.
+func ParseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	in := strings.TrimSpace(s)
+	neg := false
+	if strings.HasPrefix(in, "-") || strings.HasPrefix(in, "+") {
+		neg = in[0] == '-'
+		in = in[1:]
+	}
+	var total float64
+	found := false
+	for {
+		in = strings.TrimLeftFunc(in, func(r rune) bool { return unicode.IsSpace(r) || r == ',' })
+		in = strings.TrimPrefix(in, "and ")
+		in = strings.TrimLeftFunc(in, unicode.IsSpace)
+		if in == "" {
+			break
+		}
+		i := strings.IndexFunc(in, func(r rune) bool { return !unicode.IsDigit(r) && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("%w: %q", errInvalidDuration, s)
+		}
+		n, err := strconv.ParseFloat(in[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", errInvalidDuration, s)
+		}
+		in = strings.TrimLeftFunc(in[i:], unicode.IsSpace)
+		j := strings.IndexFunc(in, func(r rune) bool { return !unicode.IsLetter(r) })
+		if j < 0 {
+			j = len(in)
+		}
+		u, ok := durationUnitNames[strings.ToLower(in[:j])]
+		if !ok {
+			return 0, fmt.Errorf("%w: unknown unit in %q", errInvalidDuration, s)
+		}
+		total += n * float64(durationUnits[u].d)
+		in = in[j:]
+		found = true
+	}
+	// math.MaxInt64 is not representable as a float64, compare with 2^63 instead.
+	total = math.Round(total)
+	if !found || total >= 1<<63 {
+		return 0, fmt.Errorf("%w: %q", errInvalidDuration, s)
+	}
+	if neg {
+		total = -total
+	}
+	return time.Duration(total), nil
+}
+
+// formatDuration is the template func of FormatDurationWithOptions. The options can be
+// a DurationOptions or a map with the keys: largest, smallest, max_units, short, round, abs and locale.
+func formatDuration(opts interface{}, v interface{}) (string, error) {
+	d, err := toDuration(v)
+	if err != nil {
+		return "", err
+	}
+	o, err := toDurationOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	return FormatDurationWithOptions(d, o)
+}
+
+func toDurationOptions(v interface{}) (DurationOptions, error) {
+	switch o := v.(type) {
+	case DurationOptions:
+		return o, nil
+	case *DurationOptions:
+		if o != nil {
+			return *o, nil
+		}
+		return DurationOptions{}, nil
+	case nil:
+		return DurationOptions{}, nil
+	}
+	m, err := toDict(v, false)
+	if err != nil {
+		return DurationOptions{}, err
+	}
+	opts := DurationOptions{
+		Short:  IsTrue(m["short"]),
+		Round:  IsTrue(m["round"]),
+		Abs:    IsTrue(m["abs"]),
+		Locale: fmt.Sprint(Default("", m["locale"])),
+	}
+	opts.LargestUnit = fmt.Sprint(Default("", m["largest"]))
+	opts.SmallestUnit = fmt.Sprint(Default("", m["smallest"]))
+	if val, ok := m["max_units"]; ok {
+		n, err := toNumber(val)
+		if err != nil || !n.isInt || !n.i.IsInt64() {
+			return DurationOptions{}, fmt.Errorf("invalid max_units: %v", val)
+		}
+		opts.MaxUnits = int(n.i.Int64())
+	}
+	return opts, nil
+}
+
+func formatDurationUnit(l durationLocale, unit int, n int64, short bool) string {
+	if short {
+		return fmt.Sprintf("%d%s", n, durationUnits[unit].short)
+	}
+	name := l.units[unit][1]
+	if l.singular(n) {
+		name = l.units[unit][0]
+	}
+	return fmt.Sprintf("%d %s", n, name)
+}
+
+func durationUnitIndex(name string, df int) (int, error) {
+	if name == "" {
+		return df, nil
+	}
+	i, ok := durationUnitNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("invalid duration unit: %q", name)
+	}
+	return i, nil
+}
+
+// toDuration converts the given value to duration. Integers are treated as nanoseconds.
+// Strings are parsed using ParseDuration.
+func toDuration(v interface{}) (time.Duration, error) {
+	switch v := v.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return ParseDuration(v)
+	}
+	rv := reflect.ValueOf(v)
+	if k, _ := basicKind(rv); k == intKind {
+		return time.Duration(rv.Int()), nil
+	}
+	return 0, fmt.Errorf("%w: %v", errInvalidDuration, v)
+}
@@ -0,0 +1,136 @@
+package template_test
+
+import (
+	"testing"
+	"time"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestFormatDuration(t *testing.T) {
+	d := 50*time.Hour + 2*time.Minute + 3*time.Second + 450*time.Millisecond
+	testIt(t, []testCase{
+		{
+			name:     "duration",
+			template: `{{duration .}}`,
+			data:     time.Hour + 2*time.Second,
+			output:   "1 hour 2 seconds",
+		},
+		{
+			name:     "duration no trailing space",
+			template: `[{{duration .}}]`,
+			data:     2*time.Hour + 3*time.Minute,
+			output:   "[2 hours 3 minutes]",
+		},
+		{
+			name:     "duration hours as largest unit",
+			template: `{{duration .}}`,
+			data:     d,
+			output:   "50 hours 2 minutes 3 seconds",
+		},
+		{
+			name:     "duration negative",
+			template: `{{duration .}}`,
+			data:     -90 * time.Second,
+			output:   "-1 minute 30 seconds",
+		},
+		{
+			name:     "duration sub-second",
+			template: `{{duration .}}`,
+			data:     int64(time.Millisecond),
+			output:   "0 seconds",
+		},
+		{
+			name:     "format days",
+			template: `{{format_duration nil .}}`,
+			data:     d,
+			output:   "2 days 2 hours 2 minutes 3 seconds",
+		},
+		{
+			name:     "format weeks short",
+			template: `{{format_duration (map "largest" "weeks" "short" true) .}}`,
+			data:     9*24*time.Hour + time.Hour,
+			output:   "1w 2d 1h",
+		},
+		{
+			name:     "format max units",
+			template: `{{format_duration (map "max_units" 2) .}}`,
+			data:     d,
+			output:   "2 days 2 hours",
+		},
+		{
+			name:     "format max units skip zero",
+			template: `{{format_duration (map "max_units" 2) .}}`,
+			data:     24*time.Hour + 5*time.Minute,
+			output:   "1 day",
+		},
+		{
+			name:     "format round",
+			template: `{{format_duration (map "max_units" 2 "round" true) .}}`,
+			data:     time.Hour + 59*time.Minute + 40*time.Second,
+			output:   "2 hours",
+		},
+		{
+			name:     "format milliseconds",
+			template: `{{format_duration (map "smallest" "ms" "short" true) .}}`,
+			data:     3*time.Second + 450*time.Millisecond,
+			output:   "3s 450ms",
+		},
+		{
+			name:     "format abs",
+			template: `{{format_duration (map "abs" true) .}}`,
+			data:     -2 * time.Minute,
+			output:   "2 minutes",
+		},
+		{
+			name:     "format locale vi",
+			template: `{{format_duration (map "locale" "vi") .}}`,
+			data:     d,
+			output:   "2 ngày 2 giờ 2 phút 3 giây",
+		},
+		{
+			name:     "format locale de",
+			template: `{{format_duration (map "locale" "de-DE" "max_units" 2) .}}`,
+			data:     25 * time.Hour,
+			output:   "1 Tag 1 Stunde",
+		},
+		{
+			name:     "format string",
+			template: `{{format_duration nil "1 week 2 days"}}`,
+			output:   "9 days",
+		},
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		input string
+		want  time.Duration
+		err   bool
+	}{
+		{input: "1h30m", want: 90 * time.Minute},
+		{input: "2 days", want: 48 * time.Hour},
+		{input: "1 week, 2 days and 3.5 hours", want: 9*24*time.Hour + 210*time.Minute},
+		{input: "1d12h", want: 36 * time.Hour},
+		{input: "-2 mins 30 secs", want: -150 * time.Second},
+		{input: "1 fortnight", err: true},
+		{input: "", err: true},
+		{input: "days", err: true},
+		{input: "9223372036854774784 ns", want: 9223372036854774784},
+		{input: "-106751 days", want: -106751 * 24 * time.Hour},
+		{input: "9223372036854775807 ns", err: true},
+		{input: "-9223372036854775807 ns", err: true},
+		{input: "106751.99116730064 days", err: true},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			got, err := tt.ParseDuration(c.input)
+			if (err != nil) != c.err {
+				t.Fatalf("got err=%v, want err=%v", err, c.err)
+			}
+			if got != c.want {
+				t.Errorf("got result=%v, want result=%v", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EncodingFuncMap return encoding func map.
+func EncodingFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"to_json":        ToJSON,
+		"to_pretty_json": ToPrettyJSON,
+		"from_json":      FromJSON,
+		"to_yaml":        ToYAML,
+		"from_yaml":      FromYAML,
+		"to_toml":        ToTOML,
+		"from_toml":      FromTOML,
+	}
+}
+
+// ToJSON return the JSON encoding of v. HTML characters are not escaped.
+func ToJSON(v interface{}) (string, error) {
+	return toJSON(v, "")
+}
+
+// ToPrettyJSON return the JSON encoding of v indented by 2 spaces. HTML characters are not escaped.
+func ToPrettyJSON(v interface{}) (string, error) {
+	return toJSON(v, "  ")
+}
+
+// FromJSON decodes the JSON string. Objects are decoded as map[string]interface{}.
+func FromJSON(s string) (interface{}, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ToYAML return the YAML encoding of v indented by 2 spaces, without the trailing new line.
+func ToYAML(v interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// FromYAML decodes the YAML string. Mappings are decoded as map[string]interface{}.
+func FromYAML(s string) (interface{}, error) {
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ToTOML return the TOML encoding of v, which must be a map or a struct.
+func ToTOML(v interface{}) (string, error) {
+	buf := bytes.Buffer{}
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// FromTOML decodes the TOML string into a map[string]interface{}.
+func FromTOML(s string) (map[string]interface{}, error) {
+	v := make(map[string]interface{})
+	if _, err := toml.Decode(s, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func toJSON(v interface{}, indent string) (string, error) {
+	buf := bytes.Buffer{}
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
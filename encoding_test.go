@@ -0,0 +1,116 @@
+package template_test
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestEncoding(t *testing.T) {
+	type config struct {
+		Name  string   `json:"name" yaml:"name" toml:"name"`
+		Ports []int    `json:"ports" yaml:"ports" toml:"ports"`
+		Tags  []string `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty"`
+	}
+	cfg := config{Name: "<app>", Ports: []int{80, 443}}
+	cases := []struct {
+		name     string
+		template string
+		data     interface{}
+		output   string
+	}{
+		{
+			name:     "to_json",
+			template: `{{to_json .}}`,
+			data:     cfg,
+			output:   `{"name":"<app>","ports":[80,443]}`,
+		},
+		{
+			name:     "to_pretty_json",
+			template: `{{to_pretty_json .}}`,
+			data:     map[string]int{"a": 1},
+			output:   "{\n  \"a\": 1\n}",
+		},
+		{
+			name:     "from_json",
+			template: `{{$v := from_json .}}{{index $v "name"}} {{index $v "ports" 1}}`,
+			data:     `{"name":"app","ports":[80,443]}`,
+			output:   "app 443",
+		},
+		{
+			name:     "to_yaml",
+			template: `{{to_yaml .}}`,
+			data:     cfg,
+			output:   "name: <app>\nports:\n  - 80\n  - 443",
+		},
+		{
+			name:     "from_yaml",
+			template: `{{$v := from_yaml .}}{{$v.name}} {{index $v.ports 0}}`,
+			data:     "name: app\nports:\n  - 80\n",
+			output:   "app 80",
+		},
+		{
+			name:     "to_toml",
+			template: `{{to_toml .}}`,
+			data:     cfg,
+			output:   "name = \"<app>\"\nports = [80, 443]",
+		},
+		{
+			name:     "from_toml",
+			template: `{{$v := from_toml .}}{{$v.server.host}}:{{$v.server.port}}`,
+			data:     "[server]\nhost = \"localhost\"\nport = 8080\n",
+			output:   "localhost:8080",
+		},
+		{
+			name:     "round trip",
+			template: `{{. | to_yaml | from_yaml | to_json}}`,
+			data:     map[string]interface{}{"a": []interface{}{1, "x"}},
+			output:   `{"a":[1,"x"]}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Parse(c.template))
+			buff := bytes.Buffer{}
+			if err := tmpl.Execute(&buff, c.data); err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != c.output {
+				t.Errorf("got result=%s, want result=%s", buff.String(), c.output)
+			}
+		})
+	}
+}
+
+func TestEncodingError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "from_json",
+			template: `{{from_json "{"}}`,
+			err:      "unexpected end of JSON input",
+		},
+		{
+			name:     "from_yaml",
+			template: `{{from_yaml ":\n  - ["}}`,
+			err:      "yaml: did not find expected key",
+		},
+		{
+			name:     "from_toml",
+			template: `{{from_toml "x = "}}`,
+			err:      `toml: line 0 (last key "x"): unexpected EOF`,
+		},
+		{
+			name:     "to_toml not a table",
+			template: `{{to_toml 1}}`,
+			err:      "toml: top-level values must be Go maps or structs",
+		},
+		{
+			name:     "to_json func",
+			template: `{{to_json .}}`,
+			data:     func() {},
+			err:      "json: unsupported type: func()",
+		},
+	})
+}
@@ -0,0 +1,398 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
+)
+
+type (
+	// Engine loads a tree of templates from a file system and renders them by name.
+	//
+	// Files under the layout and partial directories are shared by all pages,
+	// every other file is a page. Each page is parsed into its own template set
+	// together with the layouts and partials, so pages can override the blocks
+	// declared by a layout without affecting each other.
+	// Templates are named by their slash separated path relative to the root,
+	// i.e: "layouts/base.html", "partials/header.html", "users/index.html".
+	//
+	// By default, templates are parsed once and cached. In development, Reload
+	// can be used to watch the file system and re-parse the templates on changes.
+	Engine struct {
+		fsys       fs.FS
+		text       bool
+		exts       []string
+		layoutDir  string
+		partialDir string
+		funcs      map[string]interface{}
+		leftDelim  string
+		rightDelim string
+		interval   time.Duration
+		onReload   func(error)
+
+		mu    sync.RWMutex
+		pages map[string]templateSet
+		err   error
+		done  chan struct{}
+		once  sync.Once
+	}
+
+	// EngineOption is an option to configure an Engine.
+	EngineOption func(*Engine)
+
+	// templateSet is the common behavior of text/template and html/template
+	// required by the engine.
+	templateSet interface {
+		parse(name, text string) error
+		clone() (templateSet, error)
+		lookup(name string) bool
+		ExecuteTemplate(w io.Writer, name string, data interface{}) error
+	}
+
+	textSet struct {
+		*texttemplate.Template
+	}
+
+	htmlSet struct {
+		*htmltemplate.Template
+	}
+
+	fileStamp struct {
+		modTime time.Time
+		size    int64
+	}
+)
+
+var (
+	// ErrTemplateNotFound is returned when rendering a template that doesn't exist.
+	ErrTemplateNotFound = errors.New("template not found")
+)
+
+// NewEngine return a new engine which loads and parses all templates in fsys.
+// By default, html/template is used and files with extension .html, .tmpl and .gohtml
+// are loaded. The funcs from FuncMap are always registered.
+func NewEngine(fsys fs.FS, opts ...EngineOption) (*Engine, error) {
+	e := &Engine{
+		fsys:       fsys,
+		exts:       []string{".html", ".tmpl", ".gohtml"},
+		layoutDir:  "layouts",
+		partialDir: "partials",
+		funcs:      FuncMap(),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	// take the snapshot before loading so that changes made during loading are not missed.
+	stamps, err := e.snapshot()
+	if err != nil {
+		return nil, err
+	}
+	pages, err := e.load()
+	if err != nil {
+		return nil, err
+	}
+	e.pages = pages
+	if e.interval > 0 {
+		e.done = make(chan struct{})
+		go e.watch(stamps)
+	}
+	return e, nil
+}
+
+// NewDirEngine return a new engine which loads all templates in the given directory.
+func NewDirEngine(dir string, opts ...EngineOption) (*Engine, error) {
+	return NewEngine(os.DirFS(dir), opts...)
+}
+
+// TextEngine use text/template instead of html/template.
+func TextEngine() EngineOption {
+	return func(e *Engine) {
+		e.text = true
+	}
+}
+
+// Extensions set the file extensions of the templates to be loaded.
+func Extensions(exts ...string) EngineOption {
+	return func(e *Engine) {
+		e.exts = exts
+	}
+}
+
+// LayoutDir set the directory, relative to the root, which contains the layouts.
+func LayoutDir(dir string) EngineOption {
+	return func(e *Engine) {
+		e.layoutDir = path.Clean(dir)
+	}
+}
+
+// PartialDir set the directory, relative to the root, which contains the partials.
+func PartialDir(dir string) EngineOption {
+	return func(e *Engine) {
+		e.partialDir = path.Clean(dir)
+	}
+}
+
+// Funcs adds the given funcs to the engine in addition to the funcs in FuncMap.
+// It will panic if the func is not a good func or name is not a good name.
+func Funcs(funcs map[string]interface{}) EngineOption {
+	return func(e *Engine) {
+		AddFuncs(e.funcs, funcs)
+	}
+}
+
+// Delims set the action delimiters of the templates.
+func Delims(left, right string) EngineOption {
+	return func(e *Engine) {
+		e.leftDelim = left
+		e.rightDelim = right
+	}
+}
+
+// Reload enables development mode in which the file system is polled
+// at the given interval and the templates are re-parsed when a file is added,
+// removed or changed. If reloading fails, the last good templates are kept,
+// the error is reported by Err and the reload is retried at every interval until it succeeds.
+// Close must be called to stop watching.
+func Reload(interval time.Duration) EngineOption {
+	return func(e *Engine) {
+		e.interval = interval
+	}
+}
+
+// OnReload registers a func to be called after every reload attempt in development mode,
+// with the error if any.
+func OnReload(f func(err error)) EngineOption {
+	return func(e *Engine) {
+		e.onReload = f
+	}
+}
+
+// Err return the error of the last reload, or nil if it succeeded.
+func (e *Engine) Err() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.err
+}
+
+// Close stops watching the file system in development mode.
+func (e *Engine) Close() error {
+	if e.done != nil {
+		e.once.Do(func() {
+			close(e.done)
+		})
+	}
+	return nil
+}
+
+// Render executes the page with the given name and writes the output to w.
+func (e *Engine) Render(w io.Writer, name string, data interface{}) error {
+	return e.execute(w, name, name, data)
+}
+
+// RenderLayout executes the given layout using the blocks defined by the page
+// with the given name and writes the output to w.
+func (e *Engine) RenderLayout(w io.Writer, layout string, name string, data interface{}) error {
+	return e.execute(w, name, layout, data)
+}
+
+// Names return the sorted names of all pages.
+func (e *Engine) Names() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	names := make([]string, 0, len(e.pages))
+	for name := range e.pages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (e *Engine) execute(w io.Writer, page string, name string, data interface{}) error {
+	e.mu.RLock()
+	set, ok := e.pages[page]
+	e.mu.RUnlock()
+	if !ok || !set.lookup(name) {
+		return fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return set.ExecuteTemplate(w, name, data)
+}
+
+func (e *Engine) watch(stamps map[string]fileStamp) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	// failed reports whether the last reload failed, the templates are then reloaded
+	// even if the files didn't change so that the error is cleared, i.e: after a read error.
+	failed := false
+	for {
+		select {
+		case <-e.done:
+			return
+		case <-ticker.C:
+		}
+		current, err := e.snapshot()
+		if err == nil && !failed && sameStamps(stamps, current) {
+			continue
+		}
+		var pages map[string]templateSet
+		if err == nil {
+			stamps = current
+			pages, err = e.load()
+		}
+		failed = err != nil
+		e.mu.Lock()
+		if err == nil {
+			e.pages = pages
+		}
+		e.err = err
+		e.mu.Unlock()
+		if e.onReload != nil {
+			e.onReload(err)
+		}
+	}
+}
+
+func (e *Engine) snapshot() (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp)
+	if e.interval <= 0 {
+		return stamps, nil
+	}
+	err := fs.WalkDir(e.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !e.isTemplate(p) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		stamps[p] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		return nil
+	})
+	return stamps, err
+}
+
+func sameStamps(x, y map[string]fileStamp) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for k, v := range x {
+		if w, ok := y[k]; !ok || !w.modTime.Equal(v.modTime) || w.size != v.size {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *Engine) load() (map[string]templateSet, error) {
+	shared := make([]string, 0)
+	pages := make([]string, 0)
+	err := fs.WalkDir(e.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !e.isTemplate(p) {
+			return nil
+		}
+		if inDir(e.layoutDir, p) || inDir(e.partialDir, p) {
+			shared = append(shared, p)
+			return nil
+		}
+		pages = append(pages, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	base := e.newSet()
+	for _, p := range shared {
+		if err := e.parseFile(base, p); err != nil {
+			return nil, err
+		}
+	}
+	rs := make(map[string]templateSet, len(pages))
+	for _, p := range pages {
+		set, err := base.clone()
+		if err != nil {
+			return nil, err
+		}
+		if err := e.parseFile(set, p); err != nil {
+			return nil, err
+		}
+		rs[p] = set
+	}
+	return rs, nil
+}
+
+func (e *Engine) parseFile(set templateSet, name string) error {
+	b, err := fs.ReadFile(e.fsys, name)
+	if err != nil {
+		return err
+	}
+	return set.parse(name, string(b))
+}
+
+func (e *Engine) newSet() templateSet {
+	if e.text {
+		return textSet{texttemplate.New("").Delims(e.leftDelim, e.rightDelim).Funcs(e.funcs)}
+	}
+	return htmlSet{htmltemplate.New("").Delims(e.leftDelim, e.rightDelim).Funcs(e.funcs)}
+}
+
+func (e *Engine) isTemplate(name string) bool {
+	ext := path.Ext(name)
+	for _, v := range e.exts {
+		if v == ext {
+			return true
+		}
+	}
+	return false
+}
+
+func inDir(dir string, name string) bool {
+	return dir != "" && dir != "." && strings.HasPrefix(name, dir+"/")
+}
+
+func (s textSet) parse(name, text string) error {
+	_, err := s.New(name).Parse(text)
+	return err
+}
+
+func (s textSet) clone() (templateSet, error) {
+	t, err := s.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return textSet{t}, nil
+}
+
+func (s textSet) lookup(name string) bool {
+	return s.Lookup(name) != nil
+}
+
+func (s htmlSet) parse(name, text string) error {
+	_, err := s.New(name).Parse(text)
+	return err
+}
+
+func (s htmlSet) clone() (templateSet, error) {
+	t, err := s.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return htmlSet{t}, nil
+}
+
+func (s htmlSet) lookup(name string) bool {
+	return s.Lookup(name) != nil
+}
@@ -0,0 +1,245 @@
+package template_test
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestEngine(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/base.html":    {Data: []byte(`<title>{{block "title" .}}default{{end}}</title>{{block "content" .}}{{end}}`)},
+		"partials/header.html": {Data: []byte(`<h1>{{.|upper}}</h1>`)},
+		"index.html":           {Data: []byte(`{{define "title"}}home{{end}}{{define "content"}}{{template "partials/header.html" .}}{{end}}`)},
+		"users/list.html":      {Data: []byte(`{{define "content"}}{{join "," .}}{{end}}`)},
+		"plain.html":           {Data: []byte(`<p>{{.}}</p>`)},
+		"README.md":            {Data: []byte(`{{not a template`)},
+	}
+	e, err := tt.NewEngine(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name   string
+		layout string
+		page   string
+		data   interface{}
+		output string
+	}{
+		{
+			name:   "layout with page blocks",
+			layout: "layouts/base.html",
+			page:   "index.html",
+			data:   "jack",
+			output: "<title>home</title><h1>JACK</h1>",
+		},
+		{
+			name:   "layout with default block",
+			layout: "layouts/base.html",
+			page:   "users/list.html",
+			data:   []int{1, 2},
+			output: "<title>default</title>1,2",
+		},
+		{
+			name:   "page without layout is escaped",
+			page:   "plain.html",
+			data:   "<b>",
+			output: "<p>&lt;b&gt;</p>",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buff := bytes.Buffer{}
+			if c.layout != "" {
+				err = e.RenderLayout(&buff, c.layout, c.page, c.data)
+			} else {
+				err = e.Render(&buff, c.page, c.data)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != c.output {
+				t.Errorf("got result=%s, want result=%s", buff.String(), c.output)
+			}
+		})
+	}
+	if err := e.Render(&bytes.Buffer{}, "missing.html", nil); !errors.Is(err, tt.ErrTemplateNotFound) {
+		t.Errorf("got err=%v, want err=%v", err, tt.ErrTemplateNotFound)
+	}
+	if got, want := len(e.Names()), 3; got != want {
+		t.Errorf("got %d pages, want %d pages", got, want)
+	}
+}
+
+func TestTextEngine(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/main.tmpl": {Data: []byte(`[[block "content" .]][[end]]`)},
+		"page.tmpl":         {Data: []byte(`[[define "content"]]<[[.|lower]]>[[end]]`)},
+	}
+	e, err := tt.NewEngine(fsys, tt.TextEngine(), tt.Delims("[[", "]]"), tt.Extensions(".tmpl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	buff := bytes.Buffer{}
+	if err := e.RenderLayout(&buff, "layouts/main.tmpl", "page.tmpl", "HI"); err != nil {
+		t.Fatal(err)
+	}
+	if buff.String() != "<hi>" {
+		t.Errorf("got result=%s, want result=%s", buff.String(), "<hi>")
+	}
+}
+
+func TestEngineParseError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html": {Data: []byte(`{{if}}`)},
+	}
+	if _, err := tt.NewEngine(fsys); err == nil {
+		t.Error("got err=nil, want parse error")
+	}
+}
+
+func TestEngineReload(t *testing.T) {
+	dir := t.TempDir()
+	// write to a temporary file and rename so the watcher never sees a partial file.
+	write := func(name, text string) {
+		tmp := filepath.Join(dir, name+".tmp")
+		if err := os.WriteFile(tmp, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func(e *tt.Engine) string {
+		buff := bytes.Buffer{}
+		if err := e.Render(&buff, "index.html", "jack"); err != nil {
+			t.Fatal(err)
+		}
+		return buff.String()
+	}
+	write("index.html", `hello {{.}}`)
+	reloaded := make(chan error, 10)
+	e, err := tt.NewDirEngine(dir, tt.Reload(10*time.Millisecond), tt.OnReload(func(err error) {
+		reloaded <- err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	if got := render(e); got != "hello jack" {
+		t.Errorf("got result=%s, want result=%s", got, "hello jack")
+	}
+
+	write("index.html", `hi {{.|upper}}`)
+	if err := <-reloaded; err != nil {
+		t.Fatal(err)
+	}
+	if got := render(e); got != "hi JACK" {
+		t.Errorf("got result=%s, want result=%s", got, "hi JACK")
+	}
+
+	// keep the last good templates on error.
+	write("index.html", `{{if}} broken`)
+	if err := <-reloaded; err == nil {
+		t.Fatal("got err=nil, want parse error")
+	}
+	if e.Err() == nil {
+		t.Error("got Err()=nil, want parse error")
+	}
+	if got := render(e); got != "hi JACK" {
+		t.Errorf("got result=%s, want result=%s", got, "hi JACK")
+	}
+}
+
+// flakyFS is a fs.FS which fails to open any file while failing is not zero,
+// or only the files other than the root directory while failingReads is not zero.
+type flakyFS struct {
+	fs.FS
+	failing      int32
+	failingReads int32
+}
+
+func (f *flakyFS) Open(name string) (fs.File, error) {
+	if atomic.LoadInt32(&f.failing) != 0 || (name != "." && atomic.LoadInt32(&f.failingReads) != 0) {
+		return nil, errors.New("unavailable")
+	}
+	return f.FS.Open(name)
+}
+
+func TestEngineReloadAfterSnapshotError(t *testing.T) {
+	fsys := &flakyFS{FS: fstest.MapFS{
+		"index.html": {Data: []byte(`hello {{.}}`)},
+	}}
+	reloaded := make(chan error, 10)
+	e, err := tt.NewEngine(fsys, tt.Reload(10*time.Millisecond), tt.OnReload(func(err error) {
+		reloaded <- err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	atomic.StoreInt32(&fsys.failing, 1)
+	if err := <-reloaded; err == nil {
+		t.Fatal("got err=nil, want snapshot error")
+	}
+	if e.Err() == nil {
+		t.Error("got Err()=nil, want snapshot error")
+	}
+
+	// the files didn't change but the error must be cleared.
+	atomic.StoreInt32(&fsys.failing, 0)
+	for err := range reloaded {
+		if err == nil {
+			break
+		}
+	}
+	if err := e.Err(); err != nil {
+		t.Errorf("got Err()=%v, want nil", err)
+	}
+}
+
+func TestEngineReloadAfterReadError(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(`hello {{.}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fsys := &flakyFS{FS: os.DirFS(dir)}
+	reloaded := make(chan error, 10)
+	e, err := tt.NewEngine(fsys, tt.Reload(10*time.Millisecond), tt.OnReload(func(err error) {
+		reloaded <- err
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// the changed file can be listed but not read.
+	atomic.StoreInt32(&fsys.failingReads, 1)
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte(`hi {{.}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-reloaded; err == nil {
+		t.Fatal("got err=nil, want read error")
+	}
+
+	// the file didn't change since the failed reload but it must be reloaded.
+	atomic.StoreInt32(&fsys.failingReads, 0)
+	for err := range reloaded {
+		if err == nil {
+			break
+		}
+	}
+	buff := bytes.Buffer{}
+	if err := e.Render(&buff, "index.html", "jack"); err != nil || buff.String() != "hi jack" {
+		t.Errorf("got result=%s, err=%v, want result=hi jack", buff.String(), err)
+	}
+}
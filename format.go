@@ -0,0 +1,154 @@
+package template
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	compactUnits = []struct {
+		exp    int
+		suffix string
+	}{
+		{exp: 12, suffix: "T"},
+		{exp: 9, suffix: "B"},
+		{exp: 6, suffix: "M"},
+		{exp: 3, suffix: "K"},
+	}
+)
+
+// FormatNumber formats the number using the grouping and decimal separators of the locale,
+// i.e: 1234.5 is formatted as 1,234.50 in "en" and 1.234,50 in "de" with precision 2.
+// The number is rounded half away from zero to the given number of fraction digits.
+// Unknown locales fall back to their language and then to "en".
+func FormatNumber(locale string, precision int, v interface{}) (string, error) {
+	d, err := ParseDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	if precision < 0 {
+		return "", fmt.Errorf("invalid precision: %d", precision)
+	}
+	return formatRat(numberLocaleOf(locale), d.rat(), precision, false), nil
+}
+
+// FormatCurrency formats the amount using the currency with the given ISO 4217 code,
+// its symbol and number of fraction digits, i.e: $1,234.50 in "en" or 1.234,50 € in "de".
+// Unknown currencies use their code as symbol and 2 fraction digits.
+func FormatCurrency(locale string, code string, v interface{}) (string, error) {
+	d, err := ParseDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	code = strings.ToUpper(code)
+	if !isCurrencyCode(code) {
+		return "", fmt.Errorf("invalid currency code: %q", code)
+	}
+	c, ok := currencies[code]
+	if !ok {
+		c = currency{symbol: code, digits: 2}
+	}
+	l := numberLocaleOf(locale)
+	r := d.rat()
+	s := formatRat(l, new(big.Rat).Abs(r), c.digits, false)
+	s = strings.Replace(strings.Replace(l.currency, "#", s, 1), "¤", c.symbol, 1)
+	return withSign(l, r, s), nil
+}
+
+// FormatPercent formats the ratio as a percentage of the locale, i.e: 0.256 is formatted as 25.6% in "en"
+// with precision 1.
+func FormatPercent(locale string, precision int, v interface{}) (string, error) {
+	d, err := ParseDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	if precision < 0 {
+		return "", fmt.Errorf("invalid precision: %d", precision)
+	}
+	l := numberLocaleOf(locale)
+	r := new(big.Rat).Mul(d.rat(), big.NewRat(100, 1))
+	s := formatRat(l, new(big.Rat).Abs(r), precision, false)
+	return withSign(l, r, strings.Replace(l.percent, "#", s, 1)), nil
+}
+
+// FormatCompact formats the number in the compact notation using the decimal separator of the locale,
+// i.e: 1234 is formatted as 1.2K and 3456789 as 3.5M.
+func FormatCompact(locale string, v interface{}) (string, error) {
+	d, err := ParseDecimal(v)
+	if err != nil {
+		return "", err
+	}
+	l := numberLocaleOf(locale)
+	r := d.rat()
+	abs := new(big.Rat).Abs(r)
+	// round before choosing the unit, so that i.e: 999.96 is formatted as 1K rather than 1,000.
+	q, _ := new(big.Rat).SetString(abs.FloatString(1))
+	suffix := ""
+	thousand := big.NewRat(1000, 1)
+	for i := len(compactUnits) - 1; i >= 0 && q.Cmp(thousand) >= 0; i-- {
+		unit := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(compactUnits[i].exp)), nil))
+		q, _ = new(big.Rat).SetString(new(big.Rat).Quo(abs, unit).FloatString(1))
+		suffix = compactUnits[i].suffix
+	}
+	return withSign(l, r, formatRat(l, q, 1, true)+suffix), nil
+}
+
+// formatRat formats r with the given number of fraction digits using the conventions of the locale.
+// If trim is true, trailing zeros of the fraction are removed.
+func formatRat(l numberLocale, r *big.Rat, precision int, trim bool) string {
+	s := r.FloatString(precision)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i+1:]
+	}
+	if trim {
+		frac = strings.TrimRight(frac, "0")
+	}
+	// group the integer digits from the right.
+	groups := make([]string, 0)
+	size := l.grouping[0]
+	for len(intPart) > size && size > 0 {
+		groups = append([]string{intPart[len(intPart)-size:]}, groups...)
+		intPart = intPart[:len(intPart)-size]
+		size = l.grouping[1]
+	}
+	groups = append([]string{intPart}, groups...)
+	rs := strings.Join(groups, l.group)
+	if frac != "" {
+		rs += l.decimal + frac
+	}
+	if neg && strings.Trim(s, "0.") != "" {
+		rs = l.minus() + rs
+	}
+	return rs
+}
+
+// withSign adds the minus sign of the locale to s if r is negative.
+func withSign(l numberLocale, r *big.Rat, s string) string {
+	if r.Sign() < 0 && strings.ContainsAny(s, "123456789") {
+		return l.minus() + s
+	}
+	return s
+}
+
+func (l numberLocale) minus() string {
+	if l.minusSign != "" {
+		return l.minusSign
+	}
+	return "-"
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
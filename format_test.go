@@ -0,0 +1,162 @@
+package template_test
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "en",
+			template: `{{format_number "en" 2 .}}`,
+			data:     1234567.891,
+			output:   "1,234,567.89",
+		},
+		{
+			name:     "de",
+			template: `{{format_number "de-DE" 2 .}}`,
+			data:     1234.5,
+			output:   "1.234,50",
+		},
+		{
+			name:     "fr",
+			template: `{{format_number "fr_FR" 1 .}}`,
+			data:     -1234.56,
+			output:   "-1\u202f234,6",
+		},
+		{
+			name:     "en-IN grouping",
+			template: `{{format_number "en-IN" 0 .}}`,
+			data:     1234567,
+			output:   "12,34,567",
+		},
+		{
+			name:     "de-CH",
+			template: `{{format_number "de-CH" 0 .}}`,
+			data:     "1234567",
+			output:   "1’234’567",
+		},
+		{
+			name:     "unknown locale fallback",
+			template: `{{format_number "xx" 0 .}}`,
+			data:     1234,
+			output:   "1,234",
+		},
+		{
+			name:     "small number",
+			template: `{{format_number "en" 3 .}}`,
+			data:     0.5,
+			output:   "0.500",
+		},
+	})
+}
+
+func TestFormatCurrency(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "en USD",
+			template: `{{format_currency "en-US" "USD" .}}`,
+			data:     1234.5,
+			output:   "$1,234.50",
+		},
+		{
+			name:     "en USD negative",
+			template: `{{format_currency "en-US" "usd" .}}`,
+			data:     -0.5,
+			output:   "-$0.50",
+		},
+		{
+			name:     "de EUR",
+			template: `{{format_currency "de" "EUR" .}}`,
+			data:     1234.5,
+			output:   "1.234,50\u00a0€",
+		},
+		{
+			name:     "vi VND",
+			template: `{{format_currency "vi" "VND" .}}`,
+			data:     1500000,
+			output:   "1.500.000\u00a0₫",
+		},
+		{
+			name:     "ja JPY",
+			template: `{{format_currency "ja" "JPY" .}}`,
+			data:     1234.5,
+			output:   "¥1,235",
+		},
+		{
+			name:     "KWD 3 digits",
+			template: `{{format_currency "en" "KWD" .}}`,
+			data:     "1.2345",
+			output:   "KD1.235",
+		},
+		{
+			name:     "unknown currency",
+			template: `{{format_currency "en" "XYZ" .}}`,
+			data:     1,
+			output:   "XYZ1.00",
+		},
+	})
+}
+
+func TestFormatPercentAndCompact(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "percent en",
+			template: `{{format_percent "en" 1 .}}`,
+			data:     0.256,
+			output:   "25.6%",
+		},
+		{
+			name:     "percent de",
+			template: `{{format_percent "de" 0 .}}`,
+			data:     0.25,
+			output:   "25\u00a0%",
+		},
+		{
+			name:     "compact small",
+			template: `{{format_compact "en" .}}`,
+			data:     999,
+			output:   "999",
+		},
+		{
+			name:     "compact K",
+			template: `{{format_compact "en" .}}`,
+			data:     1234,
+			output:   "1.2K",
+		},
+		{
+			name:     "compact M de",
+			template: `{{format_compact "de" .}}`,
+			data:     -3456789,
+			output:   "-3,5M",
+		},
+		{
+			name:     "compact round to next unit",
+			template: `{{format_compact "en" .}}`,
+			data:     999999,
+			output:   "1M",
+		},
+		{
+			name:     "compact round to K",
+			template: `{{format_compact "en" .}}`,
+			data:     999.96,
+			output:   "1K",
+		},
+		{
+			name:     "compact round fraction to next unit",
+			template: `{{format_compact "en" .}}`,
+			data:     999_999.6,
+			output:   "1M",
+		},
+		{
+			name:     "compact below K",
+			template: `{{format_compact "en" .}}`,
+			data:     999.94,
+			output:   "999.9",
+		},
+		{
+			name:     "compact B",
+			template: `{{format_compact "en" .}}`,
+			data:     2000000000,
+			output:   "2B",
+		},
+	})
+}
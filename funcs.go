@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"unicode"
 )
 
@@ -41,6 +43,15 @@ func FuncMap() map[string]interface{} {
 	AddFuncs(m, StringFuncMap())
 	AddFuncs(m, NumberFuncMap())
 	AddFuncs(m, TimeFuncMap())
+	AddFuncs(m, CollectionFuncMap())
+	AddFuncs(m, DictFuncMap())
+	AddFuncs(m, EncodingFuncMap())
+	AddFuncs(m, CodecFuncMap())
+	AddFuncs(m, HashFuncMap())
+	AddFuncs(m, RandFuncMap())
+	AddFuncs(m, UUIDFuncMap())
+	AddFuncs(m, CertFuncMap())
+	AddFuncs(m, InflectionFuncMap())
 	return m
 }
 
@@ -212,3 +223,129 @@ func printableValue(v reflect.Value) interface{} {
 	}
 	return v.Interface()
 }
+
+// lt evaluates the comparison a < b.
+func lt(arg1, arg2 reflect.Value) (bool, error) {
+	v1 := indirectInterface(arg1)
+	k1, err := basicKind(v1)
+	if err != nil {
+		return false, err
+	}
+	v2 := indirectInterface(arg2)
+	k2, err := basicKind(v2)
+	if err != nil {
+		return false, err
+	}
+	truth := false
+	if k1 != k2 {
+		// Special case: Can compare integer values regardless of type's sign.
+		switch {
+		case k1 == intKind && k2 == uintKind:
+			truth = v1.Int() < 0 || uint64(v1.Int()) < v2.Uint()
+		case k1 == uintKind && k2 == intKind:
+			truth = v2.Int() >= 0 && v1.Uint() < uint64(v2.Int())
+		default:
+			return false, errBadComparison
+		}
+	} else {
+		switch k1 {
+		case boolKind, complexKind:
+			return false, errBadComparisonType
+		case floatKind:
+			truth = v1.Float() < v2.Float()
+		case intKind:
+			truth = v1.Int() < v2.Int()
+		case stringKind:
+			truth = v1.String() < v2.String()
+		case uintKind:
+			truth = v1.Uint() < v2.Uint()
+		default:
+			panic("invalid kind")
+		}
+	}
+	return truth, nil
+}
+
+// evalArgs formats the list of arguments into a string like the builtin escapers of text/template.
+func evalArgs(args []interface{}) string {
+	if len(args) == 1 {
+		if s, ok := args[0].(string); ok {
+			return s
+		}
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = printableValue(reflect.ValueOf(arg))
+	}
+	return fmt.Sprint(values...)
+}
+
+// field returns the value at the end of the dotted path, i.e: "Customer.Name",
+// resolving struct fields, map keys and slice/array indexes through pointers and interfaces.
+// The zero reflect.Value is returned if the path doesn't exist.
+func field(v reflect.Value, path string) reflect.Value {
+	if path == "" {
+		return indirectInterface(v)
+	}
+	for _, name := range strings.Split(path, ".") {
+		var isNil bool
+		if v, isNil = indirect(v); isNil || !v.IsValid() {
+			return zero
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			f, ok := v.Type().FieldByName(name)
+			if !ok || f.PkgPath != "" {
+				return zero
+			}
+			// walk the embedded structs, a promoted field is missing if an embedded pointer is nil.
+			for i, x := range f.Index {
+				if i > 0 {
+					if v, isNil = indirect(v); isNil {
+						return zero
+					}
+				}
+				v = v.Field(x)
+			}
+		case reflect.Map:
+			key, ok := mapKey(v.Type().Key(), name)
+			if !ok {
+				return zero
+			}
+			v = v.MapIndex(key)
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(name)
+			if err != nil || i < 0 || i >= v.Len() {
+				return zero
+			}
+			v = v.Index(i)
+		default:
+			return zero
+		}
+	}
+	return indirectInterface(v)
+}
+
+// mapKey converts the given name to a key of the given type.
+func mapKey(typ reflect.Type, name string) (reflect.Value, bool) {
+	switch typ.Kind() {
+	case reflect.String:
+		return reflect.ValueOf(name).Convert(typ), true
+	case reflect.Interface:
+		k := reflect.ValueOf(name)
+		return k, k.Type().Implements(typ)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			return zero, false
+		}
+		return reflect.ValueOf(i).Convert(typ), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			return zero, false
+		}
+		return reflect.ValueOf(i).Convert(typ), true
+	}
+	return zero, false
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	texttemplate "text/template"
 
 	"github.com/google/uuid"
 	tt "github.com/pthethanh/template"
@@ -19,6 +20,12 @@ type (
 		data       interface{}
 		output     string
 		verifyFunc func(got string) error
+		// funcs are added to tt.FuncMap, i.e: the opt-in func maps.
+		funcs map[string]interface{}
+		// text executes the template using text/template so that the output is not escaped.
+		text bool
+		// err, if not empty, is a part of the expected execution error and the output is not verified.
+		err string
 	}
 )
 
@@ -422,20 +429,47 @@ func TestFileFormatSize(t *testing.T) {
 func testIt(t *testing.T, cases []testCase) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Parse(c.template))
-			buff := bytes.Buffer{}
-			if err := tmpl.Execute(&buff, c.data); err != nil {
+			got, err := execute(c)
+			if c.err != "" {
+				if err == nil || !strings.Contains(err.Error(), c.err) {
+					t.Errorf("got err=%v, want err=%s", err, c.err)
+				}
+				return
+			}
+			if err != nil {
 				t.Error(err)
 			}
 			if c.verifyFunc != nil {
-				if err := c.verifyFunc(buff.String()); err != nil {
+				if err := c.verifyFunc(got); err != nil {
 					t.Error(err)
 				}
 				return
 			}
-			if strings.Compare(buff.String(), c.output) != 0 {
-				t.Errorf("got result=%s, want result=%s", buff.String(), c.output)
+			if strings.Compare(got, c.output) != 0 {
+				t.Errorf("got result=%s, want result=%s", got, c.output)
 			}
 		})
 	}
 }
+
+// render executes the text template with tt.FuncMap and the given funcs and return the output.
+func render(t *testing.T, funcs map[string]interface{}, text string, data interface{}) string {
+	t.Helper()
+	got, err := execute(testCase{template: text, data: data, funcs: funcs, text: true})
+	if err != nil {
+		t.Fatalf("got err=%v, want err=nil", err)
+	}
+	return got
+}
+
+func execute(c testCase) (string, error) {
+	buff := bytes.Buffer{}
+	if c.text {
+		tmpl := texttemplate.Must(texttemplate.New("").Funcs(tt.FuncMap()).Funcs(c.funcs).Parse(c.template))
+		err := tmpl.Execute(&buff, c.data)
+		return buff.String(), err
+	}
+	tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Funcs(c.funcs).Parse(c.template))
+	err := tmpl.Execute(&buff, c.data)
+	return buff.String(), err
+}
@@ -1,5 +1,10 @@
 module github.com/pthethanh/template
 
-go 1.14
+go 1.16
 
-require github.com/google/uuid v1.1.1
+require (
+	github.com/BurntSushi/toml v1.2.1
+	github.com/google/uuid v1.6.0
+	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2
+	gopkg.in/yaml.v3 v3.0.1
+)
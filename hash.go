@@ -0,0 +1,122 @@
+package template
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/adler32"
+	"hash/crc32"
+	"hash/fnv"
+	"strings"
+)
+
+var (
+	hashAlgorithms = map[string]func() hash.Hash{
+		"md5":        md5.New,
+		"sha1":       sha1.New,
+		"sha224":     sha256.New224,
+		"sha256":     sha256.New,
+		"sha384":     sha512.New384,
+		"sha512":     sha512.New,
+		"sha512_224": sha512.New512_224,
+		"sha512_256": sha512.New512_256,
+		"crc32":      func() hash.Hash { return crc32.NewIEEE() },
+		"adler32":    func() hash.Hash { return adler32.New() },
+		"fnv32":      func() hash.Hash { return fnv.New32() },
+		"fnv32a":     func() hash.Hash { return fnv.New32a() },
+		"fnv64":      func() hash.Hash { return fnv.New64() },
+		"fnv64a":     func() hash.Hash { return fnv.New64a() },
+		"fnv128":     fnv.New128,
+		"fnv128a":    fnv.New128a,
+	}
+)
+
+// HashFuncMap return hash func map.
+func HashFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"md5sum":    hashFunc("md5"),
+		"sha1sum":   hashFunc("sha1"),
+		"sha256sum": hashFunc("sha256"),
+		"sha512sum": hashFunc("sha512"),
+		"crc32":     hashFunc("crc32"),
+		"adler32":   hashFunc("adler32"),
+		"fnv32":     hashFunc("fnv32"),
+		"fnv32a":    hashFunc("fnv32a"),
+		"fnv64":     hashFunc("fnv64"),
+		"fnv64a":    hashFunc("fnv64a"),
+		"hash":      Hash,
+		"hash_b64":  HashBase64,
+		"hmac":      HMAC,
+		"hmac_b64":  HMACBase64,
+	}
+}
+
+// Hash return the hex encoded checksum of v using the given algorithm.
+// The value can be a string, a byte slice or any value which is hashed using its string representation.
+// Supported algorithms are md5, sha1, sha224, sha256, sha384, sha512, sha512_224, sha512_256,
+// crc32, adler32, fnv32, fnv32a, fnv64, fnv64a, fnv128 and fnv128a.
+func Hash(algorithm string, v interface{}) (string, error) {
+	b, err := sum(algorithm, nil, v)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashBase64 return the base64 encoded checksum of v using the given algorithm.
+func HashBase64(algorithm string, v interface{}) (string, error) {
+	b, err := sum(algorithm, nil, v)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// HMAC return the hex encoded HMAC of v using the given algorithm and key.
+func HMAC(algorithm string, key interface{}, v interface{}) (string, error) {
+	b, err := sum(algorithm, toBytes(key), v)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HMACBase64 return the base64 encoded HMAC of v using the given algorithm and key.
+func HMACBase64(algorithm string, key interface{}, v interface{}) (string, error) {
+	b, err := sum(algorithm, toBytes(key), v)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func hashFunc(algorithm string) func(v interface{}) string {
+	return func(v interface{}) string {
+		// the algorithm is always supported.
+		rs, _ := Hash(algorithm, v)
+		return rs
+	}
+}
+
+// sum return the checksum of v, or its HMAC if key is not nil.
+func sum(algorithm string, key []byte, v interface{}) ([]byte, error) {
+	f, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash algorithm: %q", algorithm)
+	}
+	var h hash.Hash
+	if key != nil {
+		h = hmac.New(f, key)
+	} else {
+		h = f()
+	}
+	// writing to a hash never fails.
+	_, _ = h.Write(toBytes(v))
+	return h.Sum(nil), nil
+}
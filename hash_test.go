@@ -0,0 +1,87 @@
+package template_test
+
+import (
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "md5sum",
+			template: `{{md5sum .}}`,
+			data:     "hello",
+			output:   "5d41402abc4b2a76b9719d911017c592",
+		},
+		{
+			name:     "sha1sum",
+			template: `{{sha1sum .}}`,
+			data:     "hello",
+			output:   "aaf4c61ddcc5e8a2dabede0f3b482cd9aea9434d",
+		},
+		{
+			name:     "sha256sum bytes",
+			template: `{{sha256sum .}}`,
+			data:     []byte("hello"),
+			output:   "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			name:     "sha512sum",
+			template: `{{slice (sha512sum .) 0 20}}`,
+			data:     "hello",
+			output:   "9b71d224bd62f3785d96",
+		},
+		{
+			name:     "crc32",
+			template: `{{crc32 .}}`,
+			data:     "hello",
+			output:   "3610a686",
+		},
+		{
+			name:     "adler32",
+			template: `{{adler32 .}}`,
+			data:     "hello",
+			output:   "062c0215",
+		},
+		{
+			name:     "fnv32a",
+			template: `{{fnv32a .}}`,
+			data:     "hello",
+			output:   "4f9f2cab",
+		},
+		{
+			name:     "hash",
+			template: `{{hash "SHA256" .}}`,
+			data:     "hello",
+			output:   "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			name:     "hash base64",
+			template: `{{hash_b64 "sha256" .}}`,
+			data:     "hello",
+			output:   "LPJNul&#43;wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=",
+		},
+		{
+			name:     "hmac",
+			template: `{{hmac "sha256" "key" .}}`,
+			data:     "hello",
+			output:   "9307b3b915efb5171ff14d8cb55fbcc798c6c0ef1456d66ded1a6aa723a58b7b",
+		},
+		{
+			name:     "hmac base64",
+			template: `{{hmac_b64 "sha256" "key" .}}`,
+			data:     "hello",
+			output:   "kwezuRXvtRcf8U2MtV&#43;8x5jGwO8UVtZt7RpqpyOli3s=",
+		},
+	})
+}
+
+func TestHashError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "unsupported algorithm",
+			template: `{{hash "sha3" .}}`,
+			data:     "hello",
+			err:      `unsupported hash algorithm: "sha3"`,
+		},
+	})
+}
@@ -0,0 +1,338 @@
+package template
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+type (
+	// Inflector holds the inflection rules of a language.
+	Inflector interface {
+		// Singular reports whether count is used with the singular form of a word.
+		Singular(count int64) bool
+		// Pluralize return the plural form of the word.
+		Pluralize(word string) string
+		// Singularize return the singular form of the word.
+		Singularize(word string) string
+		// Ordinal return the ordinal form of n, i.e: 1st.
+		Ordinal(n int64) string
+		// NumberToWords return n spelled out in words.
+		NumberToWords(n int64) string
+	}
+
+	inflectionRule struct {
+		re   *regexp.Regexp
+		repl string
+	}
+
+	englishInflector struct{}
+)
+
+var (
+	inflectorsMu sync.RWMutex
+	inflectors   = map[string]Inflector{
+		defaultLocale: englishInflector{},
+	}
+
+	// englishPlurals are the rules to pluralize English words, the first matching rule is applied.
+	englishPlurals = inflectionRules(
+		`(?i)(quiz)$`, "${1}zes",
+		`(?i)^(oxen)$`, "${1}",
+		`(?i)^(ox)$`, "${1}en",
+		`(?i)^(m|l)ice$`, "${1}ice",
+		`(?i)^(m|l)ouse$`, "${1}ice",
+		`(?i)(matr|vert|ind)(?:ix|ex)$`, "${1}ices",
+		`(?i)(x|ch|ss|sh)$`, "${1}es",
+		`(?i)([^aeiouy]|qu)y$`, "${1}ies",
+		`(?i)(hive)$`, "${1}s",
+		`(?i)(?:([^f])fe|([lr])f)$`, "${1}${2}ves",
+		`(?i)sis$`, "ses",
+		`(?i)([ti])a$`, "${1}a",
+		`(?i)([ti])um$`, "${1}a",
+		`(?i)(buffal|tomat|potat|her|ech|vet)o$`, "${1}oes",
+		`(?i)(bu)s$`, "${1}ses",
+		`(?i)(alias|status)$`, "${1}es",
+		`(?i)(octop|vir)i$`, "${1}i",
+		`(?i)(octop|vir)us$`, "${1}i",
+		`(?i)^(ax|test)is$`, "${1}es",
+		`(?i)s$`, "s",
+		`$`, "s",
+	)
+
+	// englishSingulars are the rules to singularize English words, the first matching rule is applied.
+	englishSingulars = inflectionRules(
+		`(?i)(database)s$`, "${1}",
+		`(?i)(quiz)zes$`, "${1}",
+		`(?i)(matr)ices$`, "${1}ix",
+		`(?i)(vert|ind)ices$`, "${1}ex",
+		`(?i)^(ox)en`, "${1}",
+		`(?i)(alias|status)(es)?$`, "${1}",
+		`(?i)(octop|vir)(us|i)$`, "${1}us",
+		`(?i)^(a)x[ie]s$`, "${1}xis",
+		`(?i)(cris|test)(is|es)$`, "${1}is",
+		`(?i)(shoe)s$`, "${1}",
+		`(?i)(o)es$`, "${1}",
+		`(?i)(bus)(es)?$`, "${1}",
+		`(?i)^(m|l)ice$`, "${1}ouse",
+		`(?i)(x|ch|ss|sh)es$`, "${1}",
+		`(?i)(m)ovies$`, "${1}ovie",
+		`(?i)(s)eries$`, "${1}eries",
+		`(?i)([^aeiouy]|qu)ies$`, "${1}y",
+		`(?i)([lr])ves$`, "${1}f",
+		`(?i)(tive)s$`, "${1}",
+		`(?i)(hive)s$`, "${1}",
+		`(?i)([^f])ves$`, "${1}fe",
+		`(?i)((a)naly|(b)a|(d)iagno|(p)arenthe|(p)rogno|(s)ynop|(t)he)(sis|ses)$`, "${1}sis",
+		`(?i)([ti])a$`, "${1}um",
+		`(?i)(n)ews$`, "${1}ews",
+		`(?i)(ss)$`, "${1}",
+		`(?i)s$`, "",
+	)
+
+	// englishIrregulars maps the singular to the plural form of the irregular English words.
+	englishIrregulars = map[string]string{
+		"person": "people",
+		"man":    "men",
+		"woman":  "women",
+		"child":  "children",
+		"tooth":  "teeth",
+		"foot":   "feet",
+		"goose":  "geese",
+		"sex":    "sexes",
+		"move":   "moves",
+		"zombie": "zombies",
+	}
+
+	englishUncountables = map[string]bool{
+		"equipment": true, "information": true, "rice": true, "money": true, "species": true, "series": true,
+		"fish": true, "sheep": true, "jeans": true, "police": true, "news": true, "deer": true, "moose": true,
+	}
+
+	englishOnes = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	englishTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	englishScales = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+)
+
+// RegisterInflector registers the inflection rules of a language, i.e: "vi" or "fr-CA".
+// English (en) is registered by default and is used when the language is not registered.
+func RegisterInflector(locale string, inflector Inflector) {
+	inflectorsMu.Lock()
+	defer inflectorsMu.Unlock()
+	inflectors[normalizeLocale(locale)] = inflector
+}
+
+// InflectionFuncMap return inflection func map using the English rules.
+func InflectionFuncMap() map[string]interface{} {
+	return InflectionFuncMapWithLocale(defaultLocale)
+}
+
+// InflectionFuncMapWithLocale return inflection func map using the rules registered for the locale.
+func InflectionFuncMapWithLocale(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"pluralize": func(count interface{}, singular string, plural ...string) (string, error) {
+			return Pluralize(inflectorOf(locale), count, singular, plural...)
+		},
+		"pluralize_word": func(word string) string {
+			return inflectorOf(locale).Pluralize(word)
+		},
+		"singularize": func(word string) string {
+			return inflectorOf(locale).Singularize(word)
+		},
+		"ordinal": func(v interface{}) (string, error) {
+			n, err := toInt64(v)
+			if err != nil {
+				return "", err
+			}
+			return inflectorOf(locale).Ordinal(n), nil
+		},
+		"number_to_words": func(v interface{}) (string, error) {
+			n, err := toInt64(v)
+			if err != nil {
+				return "", err
+			}
+			return inflectorOf(locale).NumberToWords(n), nil
+		},
+	}
+}
+
+// Pluralize return the count followed by the singular or the plural form of the word depending on the count,
+// i.e: "1 item", "2 items". The plural form is derived from the singular one if it is not provided.
+func Pluralize(inflector Inflector, count interface{}, singular string, plural ...string) (string, error) {
+	n, err := toNumber(count)
+	if err != nil {
+		return "", err
+	}
+	singularForm := false
+	switch {
+	case n.isInt:
+		singularForm = n.i.IsInt64() && inflector.Singular(n.i.Int64())
+	case n.f == math.Trunc(n.f) && math.Abs(n.f) < math.MaxInt64:
+		singularForm = inflector.Singular(int64(n.f))
+	}
+	// fractions always use the plural form.
+	word := singular
+	if !singularForm {
+		if len(plural) > 0 {
+			word = plural[0]
+		} else {
+			word = inflector.Pluralize(singular)
+		}
+	}
+	return fmt.Sprintf("%v %s", count, word), nil
+}
+
+func (englishInflector) Singular(count int64) bool {
+	return count == 1
+}
+
+func (englishInflector) Pluralize(word string) string {
+	lower := strings.ToLower(word)
+	if word == "" || englishUncountables[lower] {
+		return word
+	}
+	if p, ok := englishIrregulars[lower]; ok {
+		return matchCase(word, p)
+	}
+	for _, p := range englishIrregulars {
+		if lower == p {
+			return word
+		}
+	}
+	return applyInflectionRules(englishPlurals, word)
+}
+
+func (englishInflector) Singularize(word string) string {
+	lower := strings.ToLower(word)
+	if word == "" || englishUncountables[lower] {
+		return word
+	}
+	for s, p := range englishIrregulars {
+		if lower == p || lower == s {
+			return matchCase(word, s)
+		}
+	}
+	return applyInflectionRules(englishSingulars, word)
+}
+
+func (englishInflector) Ordinal(n int64) string {
+	suffix := "th"
+	abs := n % 100
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs < 11 || abs > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+func (englishInflector) NumberToWords(n int64) string {
+	if n == 0 {
+		return englishOnes[0]
+	}
+	// use uint64 so that the absolute value of math.MinInt64 doesn't overflow.
+	u := uint64(n)
+	prefix := ""
+	if n < 0 {
+		u = uint64(-(n + 1)) + 1
+		prefix = "minus "
+	}
+	groups := make([]string, 0)
+	for scale := 0; u > 0; scale++ {
+		if g := u % 1000; g > 0 {
+			words := englishHundreds(int(g))
+			if englishScales[scale] != "" {
+				words += " " + englishScales[scale]
+			}
+			groups = append([]string{words}, groups...)
+		}
+		u /= 1000
+	}
+	return prefix + strings.Join(groups, " ")
+}
+
+// englishHundreds return the words of n in [1, 999], i.e: "one hundred twenty-three".
+func englishHundreds(n int) string {
+	words := make([]string, 0, 2)
+	if n >= 100 {
+		words = append(words, englishOnes[n/100]+" hundred")
+		n %= 100
+	}
+	switch {
+	case n >= 20 && n%10 != 0:
+		words = append(words, englishTens[n/10]+"-"+englishOnes[n%10])
+	case n >= 20:
+		words = append(words, englishTens[n/10])
+	case n > 0:
+		words = append(words, englishOnes[n])
+	}
+	return strings.Join(words, " ")
+}
+
+func inflectorOf(locale string) Inflector {
+	inflectorsMu.RLock()
+	defer inflectorsMu.RUnlock()
+	return inflectors[lookupLocale(locale, func(l string) bool {
+		_, ok := inflectors[l]
+		return ok
+	})]
+}
+
+func inflectionRules(pairs ...string) []inflectionRule {
+	rules := make([]inflectionRule, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		rules = append(rules, inflectionRule{re: regexp.MustCompile(pairs[i]), repl: pairs[i+1]})
+	}
+	return rules
+}
+
+func applyInflectionRules(rules []inflectionRule, word string) string {
+	for _, r := range rules {
+		if r.re.MatchString(word) {
+			rs := r.re.ReplaceAllString(word, r.repl)
+			if strings.ToUpper(word) == word {
+				return strings.ToUpper(rs)
+			}
+			return rs
+		}
+	}
+	return word
+}
+
+// matchCase return s in upper case if word is in upper case, or capitalized if word is capitalized.
+func matchCase(word string, s string) string {
+	if strings.ToUpper(word) == word {
+		return strings.ToUpper(s)
+	}
+	if r, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(r) {
+		return capitalize(s)
+	}
+	return s
+}
+
+// toInt64 converts the given value to int64. It must be an integer or a string of an integer.
+func toInt64(v interface{}) (int64, error) {
+	n, err := toNumber(v)
+	if err != nil {
+		return 0, err
+	}
+	if !n.isInt || !n.i.IsInt64() {
+		return 0, fmt.Errorf("invalid integer: %v", v)
+	}
+	return n.i.Int64(), nil
+}
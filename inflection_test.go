@@ -0,0 +1,177 @@
+package template_test
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"testing"
+
+	tt "github.com/pthethanh/template"
+)
+
+type viInflector struct{}
+
+func (viInflector) Singular(int64) bool            { return true }
+func (viInflector) Pluralize(word string) string   { return word }
+func (viInflector) Singularize(word string) string { return word }
+func (viInflector) Ordinal(n int64) string         { return fmt.Sprintf("thứ %d", n) }
+func (viInflector) NumberToWords(n int64) string   { return fmt.Sprint(n) }
+
+func TestPluralize(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "singular",
+			template: `{{pluralize 1 "item"}}`,
+			output:   "1 item",
+		},
+		{
+			name:     "plural",
+			template: `{{pluralize 2 "item"}} {{pluralize 0 "box"}} {{pluralize 3 "person"}}`,
+			output:   "2 items 0 boxes 3 people",
+		},
+		{
+			name:     "custom plural",
+			template: `{{pluralize . "octopus" "octopodes"}}`,
+			data:     5,
+			output:   "5 octopodes",
+		},
+		{
+			name:     "float",
+			template: `{{pluralize 1.5 "hour"}} {{pluralize 1.0 "hour"}}`,
+			output:   "1.5 hours 1 hour",
+		},
+		{
+			name:     "len",
+			template: `{{pluralize (len .) "file"}}`,
+			data:     []int{1},
+			output:   "1 file",
+		},
+	})
+}
+
+func TestInflectWords(t *testing.T) {
+	cases := map[string]string{
+		"item":     "items",
+		"box":      "boxes",
+		"church":   "churches",
+		"city":     "cities",
+		"day":      "days",
+		"knife":    "knives",
+		"wolf":     "wolves",
+		"analysis": "analyses",
+		"datum":    "data",
+		"potato":   "potatoes",
+		"bus":      "buses",
+		"status":   "statuses",
+		"octopus":  "octopi",
+		"matrix":   "matrices",
+		"mouse":    "mice",
+		"ox":       "oxen",
+		"quiz":     "quizzes",
+		"person":   "people",
+		"Child":    "Children",
+		"MAN":      "MEN",
+		"sheep":    "sheep",
+		"news":     "news",
+		"movie":    "movies",
+		"database": "databases",
+		"User":     "Users",
+	}
+	inflector := tt.InflectionFuncMap()
+	pluralize := inflector["pluralize_word"].(func(string) string)
+	singularize := inflector["singularize"].(func(string) string)
+	for singular, plural := range cases {
+		if got := pluralize(singular); got != plural {
+			t.Errorf("pluralize_word %s: got result=%s, want result=%s", singular, got, plural)
+		}
+		if got := singularize(plural); got != singular {
+			t.Errorf("singularize %s: got result=%s, want result=%s", plural, got, singular)
+		}
+		if got := pluralize(plural); got != plural {
+			t.Errorf("pluralize_word %s: got result=%s, want result=%s", plural, got, plural)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "ordinal",
+			template: `{{ordinal 1}} {{ordinal 2}} {{ordinal 3}} {{ordinal 4}} {{ordinal 11}} {{ordinal 12}} {{ordinal 13}} {{ordinal 21}} {{ordinal 22}} {{ordinal 101}} {{ordinal 111}} {{ordinal -1}}`,
+			output:   "1st 2nd 3rd 4th 11th 12th 13th 21st 22nd 101st 111th -1st",
+		},
+		{
+			name:     "ordinal string",
+			template: `{{ordinal .}}`,
+			data:     "23",
+			output:   "23rd",
+		},
+	})
+}
+
+func TestNumberToWords(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "small",
+			template: `{{number_to_words 0}}, {{number_to_words 7}}, {{number_to_words 15}}, {{number_to_words 40}}, {{number_to_words 42}}`,
+			output:   "zero, seven, fifteen, forty, forty-two",
+		},
+		{
+			name:     "large",
+			template: `{{number_to_words 123}}; {{number_to_words 1005}}; {{number_to_words 2000000}}; {{number_to_words 1234567}}`,
+			output:   "one hundred twenty-three; one thousand five; two million; one million two hundred thirty-four thousand five hundred sixty-seven",
+		},
+		{
+			name:     "negative",
+			template: `{{number_to_words -21}}`,
+			output:   "minus twenty-one",
+		},
+		{
+			name:     "min int64",
+			template: `{{number_to_words .}}`,
+			data:     int64(-9223372036854775808),
+			output:   "minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight",
+		},
+	})
+}
+
+func TestInflectorRegister(t *testing.T) {
+	tt.RegisterInflector("vi", viInflector{})
+	tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Funcs(tt.InflectionFuncMapWithLocale("vi-VN")).Parse(`{{pluralize 2 "quả táo"}}, {{ordinal 2}}`))
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "2 quả táo, thứ 2"; buf.String() != want {
+		t.Errorf("got result=%s, want result=%s", buf.String(), want)
+	}
+	// unregistered languages fall back to English.
+	tmpl = template.Must(template.New("").Funcs(tt.InflectionFuncMapWithLocale("ja")).Parse(`{{pluralize 2 "apple"}}`))
+	buf.Reset()
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "2 apples"; buf.String() != want {
+		t.Errorf("got result=%s, want result=%s", buf.String(), want)
+	}
+}
+
+func TestInflectionError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "pluralize not a number",
+			template: `{{pluralize "x" "item"}}`,
+			err:      `parsing "x": invalid syntax`,
+		},
+		{
+			name:     "ordinal float",
+			template: `{{ordinal 1.5}}`,
+			err:      "invalid integer: 1.5",
+		},
+		{
+			name:     "number_to_words not a number",
+			template: `{{number_to_words "abc"}}`,
+			err:      `parsing "abc": invalid syntax`,
+		},
+	})
+}
@@ -0,0 +1,229 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// WrapOptions configures how a text is wrapped.
+	WrapOptions struct {
+		// Separator is the line separator. Default is a new line.
+		Separator string
+		// BreakWords breaks the words longer than the width instead of leaving them on their own line.
+		BreakWords bool
+	}
+
+	runeRange struct {
+		lo, hi rune
+	}
+)
+
+var (
+	// wideRanges are the East Asian wide and fullwidth characters, and the emoji,
+	// which are displayed using 2 columns.
+	wideRanges = []runeRange{
+		{0x1100, 0x115f},   // Hangul Jamo
+		{0x231a, 0x231b},   // watch, hourglass
+		{0x2e80, 0x303e},   // CJK radicals, punctuation
+		{0x3041, 0x33ff},   // Hiragana, Katakana, CJK compatibility
+		{0x3400, 0x4dbf},   // CJK unified ideographs extension A
+		{0x4e00, 0x9fff},   // CJK unified ideographs
+		{0xa000, 0xa4cf},   // Yi
+		{0xa960, 0xa97f},   // Hangul Jamo extended A
+		{0xac00, 0xd7a3},   // Hangul syllables
+		{0xf900, 0xfaff},   // CJK compatibility ideographs
+		{0xfe10, 0xfe19},   // vertical forms
+		{0xfe30, 0xfe6f},   // CJK compatibility forms, small form variants
+		{0xff00, 0xff60},   // fullwidth forms
+		{0xffe0, 0xffe6},   // fullwidth signs
+		{0x1f1e6, 0x1f1ff}, // regional indicators
+		{0x1f300, 0x1f64f}, // pictographs, emoticons
+		{0x1f680, 0x1f6ff}, // transport and map symbols
+		{0x1f900, 0x1f9ff}, // supplemental symbols and pictographs
+		{0x1fa70, 0x1faff}, // symbols and pictographs extended A
+		{0x20000, 0x2fffd}, // CJK unified ideographs extension B to F
+		{0x30000, 0x3fffd}, // CJK unified ideographs extension G
+	}
+)
+
+// Indent return s with each non-empty line indented by n spaces.
+func Indent(n int, s string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// NIndent return s indented by n spaces and preceded by a new line,
+// i.e: to embed a block in YAML: key:{{nindent 2 .}}.
+func NIndent(n int, s string) string {
+	return "\n" + Indent(n, s)
+}
+
+// Dedent return s with the whitespace prefix common to all its non-blank lines removed.
+// Blank lines are emptied.
+func Dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	prefix := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeftFunc(line, unicode.IsSpace))]
+		if first {
+			prefix, first = indent, false
+			continue
+		}
+		i := 0
+		for i < len(prefix) && i < len(indent) && prefix[i] == indent[i] {
+			i++
+		}
+		prefix = prefix[:i]
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = line[len(prefix):]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Wrap return s wrapped at word boundaries so that its lines are at most n columns wide.
+// East Asian wide characters count as 2 columns. Words longer than n are left on their own line.
+func Wrap(n int, s string) string {
+	return WrapWithOptions(n, s, WrapOptions{})
+}
+
+// WrapWithOptions return s wrapped so that its lines are at most n columns wide using the given options.
+// Existing line breaks are kept and the spaces between words are collapsed.
+func WrapWithOptions(n int, s string, opts WrapOptions) string {
+	sep := opts.Separator
+	if sep == "" {
+		sep = "\n"
+	}
+	rs := make([]string, 0)
+	for _, para := range strings.Split(s, "\n") {
+		line := strings.Builder{}
+		width := 0
+		flush := func() {
+			rs = append(rs, line.String())
+			line.Reset()
+			width = 0
+		}
+		for _, word := range strings.Fields(para) {
+			w := DisplayWidth(word)
+			if width > 0 && width+1+w <= n {
+				line.WriteString(" ")
+				line.WriteString(word)
+				width += 1 + w
+				continue
+			}
+			if width > 0 {
+				flush()
+			}
+			if w <= n || !opts.BreakWords {
+				line.WriteString(word)
+				width = w
+				continue
+			}
+			for _, g := range graphemes(word) {
+				gw := DisplayWidth(g)
+				if width > 0 && width+gw > n {
+					flush()
+				}
+				line.WriteString(g)
+				width += gw
+			}
+		}
+		flush()
+	}
+	return strings.Join(rs, sep)
+}
+
+// PadLeft return s padded on the left with spaces to n columns.
+func PadLeft(n int, s string) string {
+	return pad(n, s, true, false)
+}
+
+// PadRight return s padded on the right with spaces to n columns.
+func PadRight(n int, s string) string {
+	return pad(n, s, false, true)
+}
+
+// Center return s padded on both sides with spaces to n columns.
+// The extra space goes to the right if the padding can't be split evenly.
+func Center(n int, s string) string {
+	return pad(n, s, true, true)
+}
+
+// DisplayWidth return the number of columns needed to display s in a terminal:
+// East Asian wide characters and emoji take 2 columns, combining marks take none.
+func DisplayWidth(s string) int {
+	w := 0
+	for _, g := range graphemes(s) {
+		w += graphemeWidth(g)
+	}
+	return w
+}
+
+// pad pads s with spaces to n columns on the left, on the right or on both sides.
+func pad(n int, s string, left, right bool) string {
+	p := n - DisplayWidth(s)
+	if p <= 0 {
+		return s
+	}
+	l := p
+	switch {
+	case left && right:
+		l = p / 2
+	case right:
+		l = 0
+	}
+	return strings.Repeat(" ", l) + s + strings.Repeat(" ", p-l)
+}
+
+func graphemeWidth(g string) int {
+	for _, r := range g {
+		if !unicode.IsPrint(r) || unicode.IsMark(r) {
+			return 0
+		}
+		for _, rg := range wideRanges {
+			if r >= rg.lo && r <= rg.hi {
+				return 2
+			}
+		}
+		// the width of a cluster is the width of its first character.
+		return 1
+	}
+	return 0
+}
+
+// wrapWith is the template func of WrapWithOptions. The options can be
+// a WrapOptions, a string used as line separator or a map with the keys: separator and break_words.
+func wrapWith(opts interface{}, n int, s string) (string, error) {
+	o := WrapOptions{}
+	switch v := opts.(type) {
+	case WrapOptions:
+		o = v
+	case string:
+		o.Separator = v
+	case nil:
+	default:
+		m, err := toDict(v, false)
+		if err != nil {
+			return "", fmt.Errorf("invalid wrap options: %w", err)
+		}
+		o.Separator = fmt.Sprint(Default("", m["separator"]))
+		o.BreakWords = IsTrue(m["break_words"])
+	}
+	return WrapWithOptions(n, s, o), nil
+}
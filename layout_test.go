@@ -0,0 +1,132 @@
+package template_test
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestLayout(t *testing.T) {
+	cases := []struct {
+		name     string
+		template string
+		data     interface{}
+		output   string
+	}{
+		{
+			name:     "indent",
+			template: `{{indent 2 .}}`,
+			data:     "a: 1\n\nb: 2",
+			output:   "  a: 1\n\n  b: 2",
+		},
+		{
+			name:     "nindent",
+			template: `spec:{{nindent 2 .}}`,
+			data:     "a: 1\nb: 2",
+			output:   "spec:\n  a: 1\n  b: 2",
+		},
+		{
+			name:     "dedent",
+			template: `{{dedent .}}`,
+			data:     "    a\n      b\n  \n    c",
+			output:   "a\n  b\n\nc",
+		},
+		{
+			name:     "wrap",
+			template: `{{wrap 10 .}}`,
+			data:     "The quick brown fox jumps over\nthe lazy dog",
+			output:   "The quick\nbrown fox\njumps over\nthe lazy\ndog",
+		},
+		{
+			name:     "wrap long word",
+			template: `{{wrap 5 .}}`,
+			data:     "a abcdefgh b",
+			output:   "a\nabcdefgh\nb",
+		},
+		{
+			name:     "wrap_with break words",
+			template: `{{wrap_with (map "break_words" true) 5 .}}`,
+			data:     "a abcdefgh b",
+			output:   "a\nabcde\nfgh b",
+		},
+		{
+			name:     "wrap_with separator",
+			template: `{{wrap_with "<br>" 9 .}}`,
+			data:     "The quick brown fox",
+			output:   "The quick<br>brown fox",
+		},
+		{
+			name:     "wrap wide characters",
+			template: `{{wrap 8 .}}`,
+			data:     "日本語 テキスト 中文",
+			output:   "日本語\nテキスト\n中文",
+		},
+		{
+			name:     "wrap_with break wide characters",
+			template: `{{wrap_with (map "break_words" true) 5 .}}`,
+			data:     "日本語テキスト",
+			output:   "日本\n語テ\nキス\nト",
+		},
+		{
+			name:     "pad_left",
+			template: `[{{pad_left 6 .}}]`,
+			data:     "abc",
+			output:   "[   abc]",
+		},
+		{
+			name:     "pad_right",
+			template: `[{{pad_right 6 .}}]`,
+			data:     "Việt",
+			output:   "[Việt  ]",
+		},
+		{
+			name:     "pad wide characters",
+			template: `[{{pad_right 6 .}}]`,
+			data:     "日本",
+			output:   "[日本  ]",
+		},
+		{
+			name:     "center",
+			template: `[{{center 8 .}}]`,
+			data:     "abc",
+			output:   "[  abc   ]",
+		},
+		{
+			name:     "pad longer",
+			template: `[{{center 2 .}}]`,
+			data:     "abc",
+			output:   "[abc]",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Parse(c.template))
+			buf := bytes.Buffer{}
+			if err := tmpl.Execute(&buf, c.data); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != c.output {
+				t.Errorf("got result=%q, want result=%q", buf.String(), c.output)
+			}
+		})
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"abc":                        3,
+		"日本語":                        6,
+		"Vie\u0323\u0302t":           4,
+		"\U0001F44D\U0001F3FD":       2,
+		"ｈｅｌｌｏ":                      10,
+		"":                           0,
+		"\U0001F1FB\U0001F1F3 flags": 8,
+	}
+	for s, want := range cases {
+		if got := tt.DisplayWidth(s); got != want {
+			t.Errorf("%q: got width=%d, want width=%d", s, got, want)
+		}
+	}
+}
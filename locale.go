@@ -0,0 +1,277 @@
+package template
+
+import (
+	"strings"
+)
+
+type (
+	// numberLocale holds the conventions to format numbers in a locale.
+	numberLocale struct {
+		decimal string
+		group   string
+		// grouping is the size of the first group of integer digits from the right
+		// and the size of the following groups, i.e: 3, 2 for 12,34,567.
+		grouping  [2]int
+		currency  string // pattern of currencies, ¤ is the symbol and # is the number.
+		percent   string // pattern of percentages, # is the number.
+		minusSign string
+	}
+
+	// currency holds the symbol and the number of fraction digits of a currency.
+	currency struct {
+		symbol string
+		digits int
+	}
+
+	// timeLocale holds the names and the date formats of a locale.
+	timeLocale struct {
+		months      [12]string
+		shortMonths [12]string
+		days        [7]string // starting on Sunday.
+		shortDays   [7]string
+		am, pm      string
+		long        string // strftime format of the long date.
+		short       string // strftime format of the short date.
+	}
+
+	// durationLocale holds the singular and plural names of the duration units,
+	// in the order of durationUnits.
+	durationLocale struct {
+		units [8][2]string
+		// singular reports whether n is used with the singular form.
+		singular func(n int64) bool
+	}
+)
+
+const (
+	defaultLocale = "en"
+
+	nbsp       = "\u00a0"
+	narrowNbsp = "\u202f"
+)
+
+var (
+	numberLocales = map[string]numberLocale{
+		"en":    {decimal: ".", group: ",", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"en-IN": {decimal: ".", group: ",", grouping: [2]int{3, 2}, currency: "¤#", percent: "#%"},
+		"hi":    {decimal: ".", group: ",", grouping: [2]int{3, 2}, currency: "¤#", percent: "#%"},
+		"de":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#" + nbsp + "%"},
+		"de-CH": {decimal: ".", group: "\u2019", grouping: [2]int{3, 3}, currency: "¤" + nbsp + "#", percent: "#%"},
+		"fr":    {decimal: ",", group: narrowNbsp, grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#" + narrowNbsp + "%"},
+		"es":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#" + nbsp + "%"},
+		"it":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#%"},
+		"pt":    {decimal: ",", group: nbsp, grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#%"},
+		"pt-BR": {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "¤" + nbsp + "#", percent: "#%"},
+		"nl":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "¤" + nbsp + "#", percent: "#%"},
+		"ru":    {decimal: ",", group: nbsp, grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#" + nbsp + "%"},
+		"pl":    {decimal: ",", group: nbsp, grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#%"},
+		"sv":    {decimal: ",", group: nbsp, grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#" + nbsp + "%", minusSign: "\u2212"},
+		"ja":    {decimal: ".", group: ",", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"zh":    {decimal: ".", group: ",", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"ko":    {decimal: ".", group: ",", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"th":    {decimal: ".", group: ",", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"id":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "¤#", percent: "#%"},
+		"vi":    {decimal: ",", group: ".", grouping: [2]int{3, 3}, currency: "#" + nbsp + "¤", percent: "#%"},
+	}
+
+	currencies = map[string]currency{
+		"USD": {symbol: "$", digits: 2},
+		"EUR": {symbol: "€", digits: 2},
+		"GBP": {symbol: "£", digits: 2},
+		"JPY": {symbol: "¥", digits: 0},
+		"CNY": {symbol: "CN¥", digits: 2},
+		"KRW": {symbol: "₩", digits: 0},
+		"VND": {symbol: "₫", digits: 0},
+		"INR": {symbol: "₹", digits: 2},
+		"RUB": {symbol: "₽", digits: 2},
+		"CHF": {symbol: "CHF", digits: 2},
+		"AUD": {symbol: "A$", digits: 2},
+		"CAD": {symbol: "CA$", digits: 2},
+		"NZD": {symbol: "NZ$", digits: 2},
+		"SGD": {symbol: "S$", digits: 2},
+		"HKD": {symbol: "HK$", digits: 2},
+		"TWD": {symbol: "NT$", digits: 2},
+		"MXN": {symbol: "MX$", digits: 2},
+		"BRL": {symbol: "R$", digits: 2},
+		"THB": {symbol: "฿", digits: 2},
+		"IDR": {symbol: "Rp", digits: 2},
+		"PLN": {symbol: "zł", digits: 2},
+		"SEK": {symbol: "kr", digits: 2},
+		"NOK": {symbol: "kr", digits: 2},
+		"DKK": {symbol: "kr.", digits: 2},
+		"KWD": {symbol: "KD", digits: 3},
+		"BHD": {symbol: "BD", digits: 3},
+	}
+)
+
+var (
+	timeLocales = map[string]timeLocale{
+		"en": {
+			months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			shortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			am:          "AM",
+			pm:          "PM",
+			long:        "%B %-d, %Y",
+			short:       "%-m/%-d/%y",
+		},
+		"vi": {
+			months:      [12]string{"tháng 1", "tháng 2", "tháng 3", "tháng 4", "tháng 5", "tháng 6", "tháng 7", "tháng 8", "tháng 9", "tháng 10", "tháng 11", "tháng 12"},
+			shortMonths: [12]string{"thg 1", "thg 2", "thg 3", "thg 4", "thg 5", "thg 6", "thg 7", "thg 8", "thg 9", "thg 10", "thg 11", "thg 12"},
+			days:        [7]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"},
+			shortDays:   [7]string{"CN", "Th 2", "Th 3", "Th 4", "Th 5", "Th 6", "Th 7"},
+			am:          "SA",
+			pm:          "CH",
+			long:        "%-d %B, %Y",
+			short:       "%d/%m/%Y",
+		},
+		"fr": {
+			months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+			days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+			am:          "AM",
+			pm:          "PM",
+			long:        "%-d %B %Y",
+			short:       "%d/%m/%Y",
+		},
+		"de": {
+			months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+			shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+			days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			shortDays:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+			am:          "AM",
+			pm:          "PM",
+			long:        "%-d. %B %Y",
+			short:       "%d.%m.%y",
+		},
+		"es": {
+			months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+			days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+			am:          "a. m.",
+			pm:          "p. m.",
+			long:        "%-d de %B de %Y",
+			short:       "%-d/%-m/%y",
+		},
+		"pt": {
+			months:      [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+			shortMonths: [12]string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+			days:        [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+			shortDays:   [7]string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+			am:          "AM",
+			pm:          "PM",
+			long:        "%-d de %B de %Y",
+			short:       "%d/%m/%Y",
+		},
+		"it": {
+			months:      [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+			shortMonths: [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+			days:        [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+			shortDays:   [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+			am:          "AM",
+			pm:          "PM",
+			long:        "%-d %B %Y",
+			short:       "%d/%m/%y",
+		},
+		"ja": {
+			months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			shortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			days:        [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+			shortDays:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+			am:          "午前",
+			pm:          "午後",
+			long:        "%Y年%-m月%-d日",
+			short:       "%Y/%m/%d",
+		},
+	}
+)
+
+var (
+	durationLocales = map[string]durationLocale{
+		"en": {
+			units: [8][2]string{
+				{"week", "weeks"}, {"day", "days"}, {"hour", "hours"}, {"minute", "minutes"}, {"second", "seconds"},
+				{"millisecond", "milliseconds"}, {"microsecond", "microseconds"}, {"nanosecond", "nanoseconds"},
+			},
+			singular: func(n int64) bool { return n == 1 },
+		},
+		"vi": {
+			units: [8][2]string{
+				{"tuần", "tuần"}, {"ngày", "ngày"}, {"giờ", "giờ"}, {"phút", "phút"}, {"giây", "giây"},
+				{"mili giây", "mili giây"}, {"micro giây", "micro giây"}, {"nano giây", "nano giây"},
+			},
+			singular: func(n int64) bool { return true },
+		},
+		"fr": {
+			units: [8][2]string{
+				{"semaine", "semaines"}, {"jour", "jours"}, {"heure", "heures"}, {"minute", "minutes"}, {"seconde", "secondes"},
+				{"milliseconde", "millisecondes"}, {"microseconde", "microsecondes"}, {"nanoseconde", "nanosecondes"},
+			},
+			singular: func(n int64) bool { return n <= 1 },
+		},
+		"de": {
+			units: [8][2]string{
+				{"Woche", "Wochen"}, {"Tag", "Tage"}, {"Stunde", "Stunden"}, {"Minute", "Minuten"}, {"Sekunde", "Sekunden"},
+				{"Millisekunde", "Millisekunden"}, {"Mikrosekunde", "Mikrosekunden"}, {"Nanosekunde", "Nanosekunden"},
+			},
+			singular: func(n int64) bool { return n == 1 },
+		},
+		"es": {
+			units: [8][2]string{
+				{"semana", "semanas"}, {"día", "días"}, {"hora", "horas"}, {"minuto", "minutos"}, {"segundo", "segundos"},
+				{"milisegundo", "milisegundos"}, {"microsegundo", "microsegundos"}, {"nanosegundo", "nanosegundos"},
+			},
+			singular: func(n int64) bool { return n == 1 },
+		},
+	}
+)
+
+// normalizeLocale converts the locale to the form "ll" or "ll-CC", i.e: en_us -> en-US.
+func normalizeLocale(locale string) string {
+	parts := strings.FieldsFunc(locale, func(r rune) bool { return r == '-' || r == '_' })
+	if len(parts) == 0 {
+		return defaultLocale
+	}
+	rs := strings.ToLower(parts[0])
+	if len(parts) > 1 {
+		rs += "-" + strings.ToUpper(parts[1])
+	}
+	return rs
+}
+
+// lookupLocale return the locale if it is supported, falling back to
+// the language of the locale and then to the default locale.
+func lookupLocale(locale string, supported func(string) bool) string {
+	l := normalizeLocale(locale)
+	if supported(l) {
+		return l
+	}
+	if i := strings.Index(l, "-"); i > 0 && supported(l[:i]) {
+		return l[:i]
+	}
+	return defaultLocale
+}
+
+func numberLocaleOf(locale string) numberLocale {
+	return numberLocales[lookupLocale(locale, func(l string) bool {
+		_, ok := numberLocales[l]
+		return ok
+	})]
+}
+
+func timeLocaleOf(locale string) timeLocale {
+	return timeLocales[lookupLocale(locale, func(l string) bool {
+		_, ok := timeLocales[l]
+		return ok
+	})]
+}
+
+func durationLocaleOf(locale string) durationLocale {
+	return durationLocales[lookupLocale(locale, func(l string) bool {
+		_, ok := durationLocales[l]
+		return ok
+	})]
+}
@@ -1,15 +1,24 @@
 package template
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 )
 
 type (
 	operator int
-	calFunc  = func(values ...interface{}) (float64, error)
+	calFunc  = func(values ...interface{}) (interface{}, error)
+
+	// number holds either an integer or a float value.
+	number struct {
+		i     *big.Int
+		f     float64
+		isInt bool
+	}
 )
 
 const (
@@ -20,59 +29,351 @@ const (
 	pow
 )
 
+var (
+	errOverflow = errors.New("integer overflow")
+
+	minInt64  = big.NewInt(math.MinInt64)
+	maxUint64 = new(big.Int).SetUint64(math.MaxUint64)
+)
+
 // NumberFuncMap return number func map.
 func NumberFuncMap() map[string]interface{} {
 	return map[string]interface{}{
-		"mul": cal(mul),
-		"add": cal(add),
-		"sum": cal(add),
-		"div": cal(div),
-		"sub": cal(sub),
-		"pow": cal(pow),
+		"mul":   cal(mul),
+		"add":   cal(add),
+		"sum":   cal(add),
+		"div":   cal(div),
+		"sub":   cal(sub),
+		"pow":   cal(pow),
+		"mod":   Mod,
+		"idiv":  IntDiv,
+		"abs":   Abs,
+		"neg":   Neg,
+		"min":   Min,
+		"max":   Max,
+		"floor": Floor,
+		"ceil":  Ceil,
+		"round": Round,
+
+		"dec":       ParseDecimal,
+		"dec_add":   DecAdd,
+		"dec_sub":   DecSub,
+		"dec_mul":   DecMul,
+		"dec_div":   DecDiv,
+		"dec_round": DecRound,
+
+		"format_number":   FormatNumber,
+		"format_currency": FormatCurrency,
+		"format_percent":  FormatPercent,
+		"format_compact":  FormatCompact,
 	}
 }
 
+// cal return a func which applies the operator on the values from left to right.
+// The result is an integer if all the values are integers and the result is
+// an integer, i.e: 4/2, otherwise it is a float64.
+// An error is returned if an integer result overflows int64 and uint64.
 func cal(op operator) calFunc {
-	return func(values ...interface{}) (float64, error) {
-		r := float64(1.0)
+	return func(values ...interface{}) (interface{}, error) {
+		var r number
 		for i, v := range values {
-			rv := reflect.ValueOf(v)
-			ik, err := basicKind(rv)
+			n, err := toNumber(v)
 			if err != nil {
-				return 0, err
-			}
-			iv := 0.0
-			switch ik {
-			case uintKind, intKind:
-				iv = float64(rv.Int())
-			case floatKind:
-				iv = rv.Float()
-			case stringKind:
-				// string will be converted to float
-				iv, err = strconv.ParseFloat(v.(string), 64)
-				if err != nil {
-					return 0, err
-				}
-			default:
-				return 0, fmt.Errorf("value must be number kind, kind: %v", ik)
+				return nil, err
 			}
 			if i == 0 {
-				r = iv
+				r = n
 				continue
 			}
+			if r.isInt && n.isInt {
+				rs, ok, err := calInt(op, r.i, n.i)
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					r = rs
+					continue
+				}
+			}
+			x, y := r.float(), n.float()
 			switch op {
 			case mul:
-				r *= iv
+				x *= y
 			case add:
-				r += iv
+				x += y
 			case div:
-				r /= iv
+				x /= y
 			case sub:
-				r -= iv
+				x -= y
 			case pow:
-				r = math.Pow(r, iv)
+				x = math.Pow(x, y)
 			}
+			r = number{f: x}
+		}
+		return r.value()
+	}
+}
+
+// calInt applies the operator on the given integers. It reports false
+// if the result is not an integer and must be calculated using floats.
+func calInt(op operator, x, y *big.Int) (number, bool, error) {
+	r := new(big.Int)
+	switch op {
+	case mul:
+		r.Mul(x, y)
+	case add:
+		r.Add(x, y)
+	case sub:
+		r.Sub(x, y)
+	case div:
+		if y.Sign() == 0 {
+			return number{}, false, errDivisionByZero
+		}
+		m := new(big.Int)
+		if r.QuoRem(x, y, m); m.Sign() != 0 {
+			return number{}, false, nil
+		}
+	case pow:
+		if y.Sign() < 0 {
+			return number{}, false, nil
+		}
+		// avoid calculating huge numbers which would overflow anyway.
+		if x.CmpAbs(big.NewInt(1)) > 0 && y.BitLen() > 7 {
+			return number{}, false, errOverflow
+		}
+		r.Exp(x, y, nil)
+	}
+	if !fitInt(r) {
+		return number{}, false, errOverflow
+	}
+	return number{i: r, isInt: true}, true, nil
+}
+
+// Mod return the remainder of x/y. The result is an integer if both values are integers.
+func Mod(x, y interface{}) (interface{}, error) {
+	a, b, err := toNumbers(x, y)
+	if err != nil {
+		return nil, err
+	}
+	if a.isInt && b.isInt {
+		if b.i.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return number{i: new(big.Int).Rem(a.i, b.i), isInt: true}.value()
+	}
+	if b.float() == 0 {
+		return nil, errDivisionByZero
+	}
+	return math.Mod(a.float(), b.float()), nil
+}
+
+// IntDiv return the integer quotient of x/y truncated toward zero.
+func IntDiv(x, y interface{}) (interface{}, error) {
+	a, b, err := toNumbers(x, y)
+	if err != nil {
+		return nil, err
+	}
+	if a.isInt && b.isInt {
+		if b.i.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return number{i: new(big.Int).Quo(a.i, b.i), isInt: true}.value()
+	}
+	if b.float() == 0 {
+		return nil, errDivisionByZero
+	}
+	return floatToInt(math.Trunc(a.float() / b.float()))
+}
+
+// Abs return the absolute value of v.
+func Abs(v interface{}) (interface{}, error) {
+	n, err := toNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	if n.isInt {
+		return number{i: new(big.Int).Abs(n.i), isInt: true}.value()
+	}
+	return math.Abs(n.f), nil
+}
+
+// Neg return the negation of v.
+func Neg(v interface{}) (interface{}, error) {
+	n, err := toNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	if n.isInt {
+		return number{i: new(big.Int).Neg(n.i), isInt: true}.value()
+	}
+	return -n.f, nil
+}
+
+// Min return the smallest of the values.
+func Min(values ...interface{}) (interface{}, error) {
+	return minMax(values, -1)
+}
+
+// Max return the largest of the values.
+func Max(values ...interface{}) (interface{}, error) {
+	return minMax(values, 1)
+}
+
+// Floor return the greatest integer value less than or equal to v.
+func Floor(v interface{}) (interface{}, error) {
+	return roundFunc(v, math.Floor)
+}
+
+// Ceil return the least integer value greater than or equal to v.
+func Ceil(v interface{}) (interface{}, error) {
+	return roundFunc(v, math.Ceil)
+}
+
+// Round return v rounded to the given number of fraction digits, half away from zero.
+func Round(precision int, v interface{}) (interface{}, error) {
+	n, err := toNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	if n.isInt {
+		if precision < 0 {
+			n.i = roundInt(n.i, -precision)
+		}
+		return n.value()
+	}
+	p := math.Pow10(precision)
+	x := n.f * p
+	switch {
+	case p == 0:
+		// rounded to a power of 10 greater than any float.
+		return 0.0, nil
+	case math.IsInf(x, 0) || math.Abs(x) >= 1<<53:
+		// v has no more digits than the precision.
+		return n.f, nil
+	}
+	return math.Round(x) / p, nil
+}
+
+// roundInt return i rounded to a multiple of 10^digits, half away from zero.
+func roundInt(i *big.Int, digits int) *big.Int {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	q, r := new(big.Int).QuoRem(i, unit, new(big.Int))
+	if r.Abs(r).Lsh(r, 1).Cmp(unit) >= 0 {
+		q.Add(q, big.NewInt(int64(i.Sign())))
+	}
+	return q.Mul(q, unit)
+}
+
+func minMax(values []interface{}, sign int) (interface{}, error) {
+	if len(values) == 0 {
+		return nil, errors.New("missing values")
+	}
+	var r number
+	for i, v := range values {
+		n, err := toNumber(v)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 || n.cmp(r)*sign > 0 {
+			r = n
 		}
-		return r, nil
 	}
+	return r.value()
+}
+
+func roundFunc(v interface{}, f func(float64) float64) (interface{}, error) {
+	n, err := toNumber(v)
+	if err != nil {
+		return nil, err
+	}
+	if n.isInt {
+		return n.value()
+	}
+	return f(n.f), nil
+}
+
+func toNumbers(x, y interface{}) (number, number, error) {
+	a, err := toNumber(x)
+	if err != nil {
+		return number{}, number{}, err
+	}
+	b, err := toNumber(y)
+	if err != nil {
+		return number{}, number{}, err
+	}
+	return a, b, nil
+}
+
+// toNumber converts v to a number. Strings are parsed as integers if possible, otherwise as floats.
+func toNumber(v interface{}) (number, error) {
+	rv := indirectInterface(reflect.ValueOf(v))
+	k, err := basicKind(rv)
+	if err != nil {
+		return number{}, err
+	}
+	switch k {
+	case intKind:
+		return number{i: big.NewInt(rv.Int()), isInt: true}, nil
+	case uintKind:
+		return number{i: new(big.Int).SetUint64(rv.Uint()), isInt: true}, nil
+	case floatKind:
+		return number{f: rv.Float()}, nil
+	case stringKind:
+		// string will be converted to integer or float.
+		if i, ok := new(big.Int).SetString(rv.String(), 10); ok && fitInt(i) {
+			return number{i: i, isInt: true}, nil
+		}
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return number{}, err
+		}
+		return number{f: f}, nil
+	}
+	return number{}, fmt.Errorf("value must be number kind, kind: %v", k)
+}
+
+func (n number) float() float64 {
+	if n.isInt {
+		f, _ := new(big.Float).SetInt(n.i).Float64()
+		return f
+	}
+	return n.f
+}
+
+func (n number) cmp(o number) int {
+	if n.isInt && o.isInt {
+		return n.i.Cmp(o.i)
+	}
+	x, y := n.float(), o.float()
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// value return the number as an int64, or an uint64 if it doesn't fit int64, or a float64.
+func (n number) value() (interface{}, error) {
+	switch {
+	case !n.isInt:
+		return n.f, nil
+	case n.i.IsInt64():
+		return n.i.Int64(), nil
+	case n.i.IsUint64():
+		return n.i.Uint64(), nil
+	}
+	return nil, errOverflow
+}
+
+func fitInt(i *big.Int) bool {
+	return i.Cmp(minInt64) >= 0 && i.Cmp(maxUint64) <= 0
+}
+
+func floatToInt(f float64) (interface{}, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return nil, errOverflow
+	}
+	return int64(f), nil
 }
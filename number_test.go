@@ -1,6 +1,9 @@
 package template_test
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestNumber(t *testing.T) {
 	testIt(t, []testCase{
@@ -51,3 +54,166 @@ func TestNumber(t *testing.T) {
 		},
 	})
 }
+
+func TestNumberInteger(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "add keep integer",
+			template: `{{printf "%T %v" (add 1 2) (add 1 2)}}`,
+			output:   "int64 3",
+		},
+		{
+			name:     "add large integer",
+			template: `{{add . 1}}`,
+			data:     int64(1<<53 + 1),
+			output:   "9007199254740994",
+		},
+		{
+			name:     "add uint",
+			template: `{{add . 1}}`,
+			data:     uint8(2),
+			output:   "3",
+		},
+		{
+			name:     "add large uint",
+			template: `{{add . 0}}`,
+			data:     uint64(1<<64 - 1),
+			output:   "18446744073709551615",
+		},
+		{
+			name:     "div integer result",
+			template: `{{printf "%T" (div 4 2)}}`,
+			output:   "int64",
+		},
+		{
+			name:     "div float result",
+			template: `{{printf "%T" (div 3 2)}}`,
+			output:   "float64",
+		},
+		{
+			name:     "mixed float",
+			template: `{{add 1 0.5}}`,
+			output:   "1.5",
+		},
+		{
+			name:     "string integer",
+			template: `{{printf "%T" (add "1" 2)}}`,
+			output:   "int64",
+		},
+		{
+			name:     "mod",
+			template: `{{mod 7 3}}`,
+			output:   "1",
+		},
+		{
+			name:     "mod float",
+			template: `{{mod 7.5 2}}`,
+			output:   "1.5",
+		},
+		{
+			name:     "idiv",
+			template: `{{idiv -7 2}}`,
+			output:   "-3",
+		},
+		{
+			name:     "idiv float",
+			template: `{{idiv 7.9 2}}`,
+			output:   "3",
+		},
+		{
+			name:     "abs",
+			template: `{{abs -3}}`,
+			output:   "3",
+		},
+		{
+			name:     "neg",
+			template: `{{neg 2.5}}`,
+			output:   "-2.5",
+		},
+		{
+			name:     "min",
+			template: `{{min 3 1.5 2}}`,
+			output:   "1.5",
+		},
+		{
+			name:     "max",
+			template: `{{max 3 1.5 2 "4"}}`,
+			output:   "4",
+		},
+		{
+			name:     "floor",
+			template: `{{floor -1.5}}`,
+			output:   "-2",
+		},
+		{
+			name:     "ceil",
+			template: `{{ceil 1.2}}`,
+			output:   "2",
+		},
+		{
+			name:     "round",
+			template: `{{.|round 2}}`,
+			data:     3.14159,
+			output:   "3.14",
+		},
+		{
+			name:     "round integer",
+			template: `{{round 2 3}}`,
+			output:   "3",
+		},
+		{
+			name:     "round integer negative precision",
+			template: `{{round -2 1234}} {{round -2 1250}} {{round -2 -1250}} {{round -1 -1234}} {{round -2 1234.0}} {{round -3 49}}`,
+			output:   "1200 1300 -1300 -1230 1200 0",
+		},
+		{
+			name:     "round big integer",
+			template: `{{round -1 .}}`,
+			data:     uint64(18446744073709551605),
+			output:   "18446744073709551610",
+		},
+		{
+			name:     "round float out of range precision",
+			template: `{{round 400 1.5}} {{round 20 .}} {{round -400 1.5}} {{round -2 1234.5}}`,
+			data:     1e300,
+			output:   "1.5 1e&#43;300 0 1200",
+		},
+	})
+}
+
+func TestNumberError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "add overflow",
+			template: `{{add . .}}`,
+			data:     uint64(1<<64 - 1),
+			err:      "integer overflow",
+		},
+		{
+			name:     "mul overflow",
+			template: `{{mul . 3}}`,
+			data:     int64(math.MinInt64),
+			err:      "integer overflow",
+		},
+		{
+			name:     "pow overflow",
+			template: `{{pow 10 20}}`,
+			err:      "integer overflow",
+		},
+		{
+			name:     "integer division by zero",
+			template: `{{div 1 0}}`,
+			err:      "division by zero",
+		},
+		{
+			name:     "mod by zero",
+			template: `{{mod 1 0}}`,
+			err:      "division by zero",
+		},
+		{
+			name:     "float mod by zero",
+			template: `{{mod 1.5 0}}`,
+			err:      "division by zero",
+		},
+	})
+}
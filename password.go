@@ -0,0 +1,117 @@
+package template
+
+import (
+	crand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// argon2id parameters of the second recommended option of RFC 9106 for memory-constrained environments:
+	// t=3 iterations with 64 MiB of memory.
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+)
+
+var (
+	passwordClasses = map[string]string{
+		"lower":  "abcdefghijklmnopqrstuvwxyz",
+		"upper":  "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+		"digit":  "0123456789",
+		"symbol": "!#$%&()*+,-./:;<=>?@[]^_{|}~",
+	}
+	defaultPasswordClasses = []string{"lower", "upper", "digit", "symbol"}
+)
+
+// PasswordFuncMap return password hashing and generating func map.
+// It is not part of FuncMap and must be added explicitly.
+func PasswordFuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"bcrypt":            Bcrypt,
+		"htpasswd":          Htpasswd,
+		"argon2id":          Argon2id,
+		"generate_password": GeneratePassword,
+	}
+}
+
+// Bcrypt return the bcrypt hash of the password using the given cost,
+// or bcrypt.DefaultCost if the cost is not provided.
+func Bcrypt(password string, cost ...int) (string, error) {
+	c := bcrypt.DefaultCost
+	if len(cost) > 0 {
+		c = cost[0]
+	}
+	if c < bcrypt.MinCost || c > bcrypt.MaxCost {
+		return "", fmt.Errorf("invalid bcrypt cost %d, want value in [%d, %d]", c, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	b, err := bcrypt.GenerateFromPassword([]byte(password), c)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Htpasswd return a htpasswd line of the user with the bcrypt hash of the password,
+// i.e: "admin:$2a$10$...".
+func Htpasswd(user string, password string) (string, error) {
+	if user == "" || strings.ContainsAny(user, ":\r\n") {
+		return "", fmt.Errorf("invalid htpasswd user: %q", user)
+	}
+	h, err := Bcrypt(password)
+	if err != nil {
+		return "", err
+	}
+	return user + ":" + h, nil
+}
+
+// Argon2id return the argon2id hash of the password with a random salt in PHC string format,
+// i.e: "$argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>".
+func Argon2id(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := crand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	enc := base64.RawStdEncoding
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argon2Memory, argon2Time, argon2Threads, enc.EncodeToString(salt), enc.EncodeToString(key)), nil
+}
+
+// GeneratePassword return a random password of the given length using crypto/rand.
+// The password contains at least one character of each of the given classes:
+// lower, upper, digit and symbol. All classes are used if none is provided.
+func GeneratePassword(length int, classes ...string) (string, error) {
+	if len(classes) == 0 {
+		classes = defaultPasswordClasses
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("password length %d is too short for %d character classes", length, len(classes))
+	}
+	r := rand.New(cryptoSource{})
+	chars := ""
+	b := make([]byte, 0, length)
+	for _, class := range classes {
+		cs, ok := passwordClasses[strings.ToLower(class)]
+		if !ok {
+			return "", fmt.Errorf("invalid character class: %q", class)
+		}
+		if strings.Contains(chars, cs) {
+			return "", fmt.Errorf("duplicated character class: %q", class)
+		}
+		chars += cs
+		b = append(b, cs[r.Intn(len(cs))])
+	}
+	for len(b) < length {
+		b = append(b, chars[r.Intn(len(chars))])
+	}
+	r.Shuffle(len(b), func(i, j int) { b[i], b[j] = b[j], b[i] })
+	return string(b), nil
+}
@@ -0,0 +1,124 @@
+package template_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	tt "github.com/pthethanh/template"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordNotInFuncMap(t *testing.T) {
+	m := tt.FuncMap()
+	for name := range tt.PasswordFuncMap() {
+		if _, ok := m[name]; ok {
+			t.Errorf("got %s in FuncMap, want opt-in only", name)
+		}
+	}
+}
+
+func TestBcrypt(t *testing.T) {
+	h := render(t, tt.PasswordFuncMap(), `{{bcrypt . 4}}`, "secret")
+	if cost, err := bcrypt.Cost([]byte(h)); err != nil || cost != 4 {
+		t.Errorf("got cost=%d, err=%v, want cost=4", cost, err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(h), []byte("secret")); err != nil {
+		t.Errorf("got err=%v, want hash matches the password", err)
+	}
+	h = render(t, tt.PasswordFuncMap(), `{{bcrypt .}}`, "secret")
+	if cost, _ := bcrypt.Cost([]byte(h)); cost != bcrypt.DefaultCost {
+		t.Errorf("got cost=%d, want cost=%d", cost, bcrypt.DefaultCost)
+	}
+}
+
+func TestHtpasswd(t *testing.T) {
+	line := render(t, tt.PasswordFuncMap(), `{{htpasswd "admin" .}}`, "secret")
+	i := strings.Index(line, ":")
+	if i < 0 || line[:i] != "admin" {
+		t.Fatalf("got %q, want admin:<hash>", line)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(line[i+1:]), []byte("secret")); err != nil {
+		t.Errorf("got err=%v, want hash matches the password", err)
+	}
+}
+
+func TestArgon2id(t *testing.T) {
+	h := render(t, tt.PasswordFuncMap(), `{{argon2id .}}`, "secret")
+	parts := strings.Split(h, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" || parts[2] != "v=19" || parts[3] != "m=65536,t=3,p=4" {
+		t.Fatalf("got %q, want argon2id PHC string", h)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := argon2.IDKey([]byte("secret"), salt, 3, 64*1024, 4, 32)
+	if base64.RawStdEncoding.EncodeToString(key) != parts[5] {
+		t.Errorf("got hash=%s, want hash matches the password", parts[5])
+	}
+	if h == render(t, tt.PasswordFuncMap(), `{{argon2id .}}`, "secret") {
+		t.Error("got same hashes, want random salt")
+	}
+}
+
+func TestGeneratePassword(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		p := render(t, tt.PasswordFuncMap(), `{{generate_password 12}}`, nil)
+		if len(p) != 12 {
+			t.Fatalf("got %q, want 12 characters", p)
+		}
+		for _, class := range []string{"abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "0123456789"} {
+			if !strings.ContainsAny(p, class) {
+				t.Fatalf("got %q, want at least one of %s", p, class)
+			}
+		}
+		p = render(t, tt.PasswordFuncMap(), `{{generate_password 8 "digit" "upper"}}`, nil)
+		if len(p) != 8 || strings.Trim(p, "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" ||
+			!strings.ContainsAny(p, "0123456789") || !strings.ContainsAny(p, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			t.Fatalf("got %q, want 8 digits and upper case letters", p)
+		}
+	}
+}
+
+func TestPasswordError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "bcrypt cost",
+			template: `{{bcrypt "x" 100}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      "invalid bcrypt cost 100",
+		},
+		{
+			name:     "htpasswd colon",
+			template: `{{htpasswd "a:b" "x"}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      `invalid htpasswd user: "a:b"`,
+		},
+		{
+			name:     "htpasswd empty",
+			template: `{{htpasswd "" "x"}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      `invalid htpasswd user: ""`,
+		},
+		{
+			name:     "too short",
+			template: `{{generate_password 3}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      "password length 3 is too short for 4 character classes",
+		},
+		{
+			name:     "unknown class",
+			template: `{{generate_password 8 "emoji"}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      `invalid character class: "emoji"`,
+		},
+		{
+			name:     "duplicated class",
+			template: `{{generate_password 8 "digit" "digit"}}`,
+			funcs:    tt.PasswordFuncMap(),
+			err:      `duplicated character class: "digit"`,
+		},
+	})
+}
@@ -0,0 +1,169 @@
+package template
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type (
+	// lockedRand is a *rand.Rand safe for concurrent use.
+	lockedRand struct {
+		mu sync.Mutex
+		r  *rand.Rand
+		// read, if not nil, is used instead of r to generate random bytes, i.e: crypto/rand.Read.
+		read func(b []byte) (int, error)
+	}
+
+	// cryptoSource is a rand.Source64 backed by crypto/rand.
+	cryptoSource struct{}
+)
+
+const (
+	alphaChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	alnumChars = alphaChars + "0123456789"
+)
+
+// RandFuncMap return random func map using a source seeded with the current time.
+func RandFuncMap() map[string]interface{} {
+	return RandFuncMapWithSeed(time.Now().UnixNano())
+}
+
+// RandFuncMapWithSeed return random func map using a source with the given seed,
+// so that renders are deterministic. It must not be used to generate secrets.
+func RandFuncMapWithSeed(seed int64) map[string]interface{} {
+	return randFuncMap(&lockedRand{r: rand.New(rand.NewSource(seed))})
+}
+
+// CryptoRandFuncMap return random func map using crypto/rand, suitable to generate secrets.
+func CryptoRandFuncMap() map[string]interface{} {
+	return randFuncMap(&lockedRand{r: rand.New(cryptoSource{}), read: crand.Read})
+}
+
+func randFuncMap(r *lockedRand) map[string]interface{} {
+	return map[string]interface{}{
+		// rand_int return a random integer in [min, max).
+		"rand_int": func(min, max int) (int, error) {
+			if max <= min {
+				return 0, errors.New("max must be greater than min")
+			}
+			return min + r.intn(max-min), nil
+		},
+		// rand_float return a random float in [min, max).
+		"rand_float": func(min, max float64) (float64, error) {
+			if max <= min {
+				return 0, errors.New("max must be greater than min")
+			}
+			return min + r.float64()*(max-min), nil
+		},
+		"rand_alpha": func(n int) string {
+			return r.string(alphaChars, n)
+		},
+		"rand_alnum": func(n int) string {
+			return r.string(alnumChars, n)
+		},
+		"rand_ascii": func(n int) string {
+			return r.string(printableASCII(), n)
+		},
+		// rand_choice return one of the values, or one of the elements if a single slice is given.
+		"rand_choice": func(values ...interface{}) (interface{}, error) {
+			v := reflect.ValueOf(values)
+			if len(values) == 1 {
+				if s, err := toSlice(values[0]); err == nil {
+					v = s
+				}
+			}
+			if v.Len() == 0 {
+				return nil, errors.New("missing values")
+			}
+			return v.Index(r.intn(v.Len())).Interface(), nil
+		},
+		// shuffle return a new list holding the elements of the collection in random order.
+		"shuffle": func(collection interface{}) (interface{}, error) {
+			v, err := toSlice(collection)
+			if err != nil {
+				return nil, err
+			}
+			rs := copySlice(v)
+			r.shuffle(rs.Len(), reflect.Swapper(rs.Interface()))
+			return rs.Interface(), nil
+		},
+		// rand_bytes return n random bytes, to be used with an encoder, i.e: rand_bytes 32|b64enc.
+		"rand_bytes": func(n int) ([]byte, error) {
+			if n < 0 {
+				return nil, errors.New("n must not be negative")
+			}
+			b := make([]byte, n)
+			if err := r.bytes(b); err != nil {
+				return nil, err
+			}
+			return b, nil
+		},
+	}
+}
+
+func (l *lockedRand) intn(n int) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Intn(n)
+}
+
+func (l *lockedRand) float64() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Float64()
+}
+
+func (l *lockedRand) shuffle(n int, swap func(i, j int)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.r.Shuffle(n, swap)
+}
+
+func (l *lockedRand) string(chars string, n int) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[l.r.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+func (l *lockedRand) bytes(b []byte) error {
+	if l.read != nil {
+		_, err := l.read(b)
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// Rand.Read always returns len(b) and a nil error.
+	_, _ = l.r.Read(b)
+	return nil
+}
+
+func printableASCII() string {
+	b := make([]byte, 0, '~'-' '+1)
+	for c := byte(' '); c <= '~'; c++ {
+		b = append(b, c)
+	}
+	return string(b)
+}
+
+func (cryptoSource) Int63() int64 {
+	return int64(cryptoSource{}.Uint64() & (1<<63 - 1))
+}
+
+func (cryptoSource) Uint64() uint64 {
+	b := [8]byte{}
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (cryptoSource) Seed(int64) {}
@@ -0,0 +1,139 @@
+package template_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestRandSeed(t *testing.T) {
+	text := `{{rand_int 0 1000}} {{rand_float 0 1}} {{rand_alpha 8}} {{rand_alnum 8}} {{rand_ascii 8}} {{rand_choice "a" "b" "c"}} {{shuffle .}} {{rand_bytes 8|hex_encode}}`
+	data := []int{1, 2, 3, 4, 5}
+	r1 := render(t, tt.RandFuncMapWithSeed(42), text, data)
+	r2 := render(t, tt.RandFuncMapWithSeed(42), text, data)
+	if r1 != r2 {
+		t.Errorf("got different outputs with the same seed: %q, %q", r1, r2)
+	}
+	if r3 := render(t, tt.RandFuncMapWithSeed(43), text, data); r3 == r1 {
+		t.Errorf("got same outputs with different seeds: %q", r3)
+	}
+}
+
+func TestRand(t *testing.T) {
+	maps := map[string]map[string]interface{}{
+		"default": tt.RandFuncMap(),
+		"seed":    tt.RandFuncMapWithSeed(1),
+		"crypto":  tt.CryptoRandFuncMap(),
+	}
+	for name, funcs := range maps {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				n, err := strconv.Atoi(render(t, funcs, `{{rand_int -5 5}}`, nil))
+				if err != nil || n < -5 || n >= 5 {
+					t.Fatalf("rand_int: got %d, want value in [-5, 5)", n)
+				}
+				f, err := strconv.ParseFloat(render(t, funcs, `{{rand_float 1.5 2}}`, nil), 64)
+				if err != nil || f < 1.5 || f >= 2 {
+					t.Fatalf("rand_float: got %v, want value in [1.5, 2)", f)
+				}
+			}
+			if s := render(t, funcs, `{{rand_alpha 20}}`, nil); len(s) != 20 || strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+				t.Errorf("rand_alpha: got %q", s)
+			}
+			if s := render(t, funcs, `{{rand_alnum 20}}`, nil); len(s) != 20 || strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" {
+				t.Errorf("rand_alnum: got %q", s)
+			}
+			if s := render(t, funcs, `{{rand_ascii 20}}`, nil); len(s) != 20 || strings.IndexFunc(s, func(r rune) bool { return r < ' ' || r > '~' }) >= 0 {
+				t.Errorf("rand_ascii: got %q", s)
+			}
+			if s := render(t, funcs, `{{rand_choice .}}`, []string{"x", "y"}); s != "x" && s != "y" {
+				t.Errorf("rand_choice: got %q, want x or y", s)
+			}
+			if s := render(t, funcs, `{{rand_choice "z"}}`, nil); s != "z" {
+				t.Errorf("rand_choice: got %q, want z", s)
+			}
+			if s := render(t, funcs, `{{sort (shuffle .)}}`, []int{3, 1, 2}); s != "[1 2 3]" {
+				t.Errorf("shuffle: got %q, want [1 2 3]", s)
+			}
+			if s := render(t, funcs, `{{rand_bytes 16|hex_encode}}`, nil); len(s) != 32 {
+				t.Errorf("rand_bytes: got %q, want 32 hex characters", s)
+			}
+		})
+	}
+}
+
+func TestRandShuffleCopy(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	render(t, tt.RandFuncMapWithSeed(1), `{{shuffle .}}`, data)
+	for i, v := range data {
+		if v != i+1 {
+			t.Fatalf("got input modified: %v", data)
+		}
+	}
+}
+
+func TestRandError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "rand_int",
+			template: `{{rand_int 5 5}}`,
+			err:      "max must be greater than min",
+		},
+		{
+			name:     "rand_float",
+			template: `{{rand_float 2 1}}`,
+			err:      "max must be greater than min",
+		},
+		{
+			name:     "rand_choice no values",
+			template: `{{rand_choice}}`,
+			err:      "missing values",
+		},
+		{
+			name:     "rand_choice empty list",
+			template: `{{rand_choice .}}`,
+			data:     []int{},
+			err:      "missing values",
+		},
+		{
+			name:     "rand_bytes",
+			template: `{{rand_bytes -1}}`,
+			err:      "n must not be negative",
+		},
+		{
+			name:     "shuffle not a collection",
+			template: `{{shuffle 1}}`,
+			err:      "value must be a slice or array",
+		},
+	})
+}
+
+// TestRandParallel renders the same templates concurrently, it is meant to be run with -race.
+func TestRandParallel(t *testing.T) {
+	text := `{{rand_int 0 10}} {{rand_float 0 1}} {{rand_alnum 5}} {{rand_choice 1 2}} {{shuffle .}} {{rand_bytes 5}}`
+	for name, funcs := range map[string]map[string]interface{}{
+		"seed":   tt.RandFuncMapWithSeed(1),
+		"crypto": tt.CryptoRandFuncMap(),
+	} {
+		tmpl := template.Must(template.New(name).Funcs(funcs).Parse(text))
+		wg := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					if err := tmpl.Execute(&bytes.Buffer{}, []int{1, 2, 3}); err != nil {
+						t.Errorf("%s: got err=%v, want err=nil", name, err)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,133 @@
+package template
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+)
+
+type (
+	// regexpCache is a LRU cache of compiled regular expressions, safe for concurrent use.
+	regexpCache struct {
+		mu    sync.Mutex
+		size  int
+		ll    *list.List
+		items map[string]*list.Element
+	}
+)
+
+const (
+	regexpCacheSize = 256
+)
+
+var (
+	regexps = newRegexpCache(regexpCacheSize)
+)
+
+// RegexMatch report whether s contains any match of the regular expression pattern.
+func RegexMatch(pattern string, s string) (bool, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(s), nil
+}
+
+// RegexFind return the leftmost match of the regular expression pattern in s,
+// or an empty string if there is no match.
+func RegexFind(pattern string, s string) (string, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.FindString(s), nil
+}
+
+// RegexFindAll return at most n successive matches of the regular expression pattern in s.
+// All matches are returned if n is negative.
+func RegexFindAll(pattern string, n int, s string) ([]string, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.FindAllString(s, n), nil
+}
+
+// RegexReplace return s with the matches of the regular expression pattern replaced by repl.
+// Inside repl, $1 or ${name} are expanded to the text of the corresponding capture group,
+// i.e: regex_replace "(\\w+)@(\\w+)" "$2 at $1" "jack@home" => "home at jack".
+func RegexReplace(pattern string, repl string, s string) (string, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(s, repl), nil
+}
+
+// RegexSplit splits s into at most n substrings separated by the matches of the regular expression pattern.
+// All substrings are returned if n is negative.
+func RegexSplit(pattern string, n int, s string) ([]string, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return re.Split(s, n), nil
+}
+
+// RegexSubmatch return the named capture groups of the leftmost match of the regular expression pattern in s,
+// i.e: regex_submatch "(?P<user>\\w+)@(?P<host>\\w+)" "jack@home" => map[host:home user:jack].
+// The map is empty if there is no match.
+func RegexSubmatch(pattern string, s string) (map[string]string, error) {
+	re, err := regexps.get(pattern)
+	if err != nil {
+		return nil, err
+	}
+	rs := make(map[string]string)
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return rs, nil
+	}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			rs[name] = m[i]
+		}
+	}
+	return rs, nil
+}
+
+func newRegexpCache(size int) *regexpCache {
+	return &regexpCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// get return the compiled regular expression of the pattern, compiling and caching it if needed.
+func (c *regexpCache) get(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if e, ok := c.items[pattern]; ok {
+		c.ll.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*regexp.Regexp), nil
+	}
+	c.mu.Unlock()
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[pattern]; ok {
+		// compiled concurrently by another caller.
+		c.ll.MoveToFront(e)
+		return e.Value.(*regexp.Regexp), nil
+	}
+	c.items[pattern] = c.ll.PushFront(re)
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*regexp.Regexp).String())
+	}
+	return re, nil
+}
@@ -0,0 +1,111 @@
+package template_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestRegex(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "regex_match",
+			template: `{{regex_match "^[a-z]+\\d$" .}} {{regex_match "^\\d+$" .}}`,
+			data:     "abc1",
+			output:   "true false",
+		},
+		{
+			name:     "regex_find",
+			template: `{{regex_find "\\d+" .}}`,
+			data:     "order 123 of 456",
+			output:   "123",
+		},
+		{
+			name:     "regex_find no match",
+			template: `[{{regex_find "\\d+" .}}]`,
+			data:     "none",
+			output:   "[]",
+		},
+		{
+			name:     "regex_find_all",
+			template: `{{regex_find_all "\\d+" -1 .}} {{regex_find_all "\\d+" 1 .}}`,
+			data:     "order 123 of 456",
+			output:   "[123 456] [123]",
+		},
+		{
+			name:     "regex_replace",
+			template: `{{regex_replace "(\\w+)@(\\w+)" "$2 at ${1}" .}}`,
+			data:     "jack@home, jill@work",
+			output:   "home at jack, work at jill",
+		},
+		{
+			name:     "regex_replace named group",
+			template: `{{regex_replace "(?P<y>\\d{4})-(?P<m>\\d{2})" "${m}/${y}" .}}`,
+			data:     "2021-03",
+			output:   "03/2021",
+		},
+		{
+			name:     "regex_split",
+			template: `{{regex_split "\\s*,\\s*" -1 .}} {{len (regex_split "," 2 .)}}`,
+			data:     "a , b,c",
+			output:   "[a b c] 2",
+		},
+		{
+			name:     "regex_submatch",
+			template: `{{$m := regex_submatch "(?P<user>\\w+)@(?P<host>[\\w.]+)" .}}{{$m.user}} {{$m.host}} {{len $m}}`,
+			data:     "mail jack@example.com now",
+			output:   "jack example.com 2",
+		},
+		{
+			name:     "regex_submatch no match",
+			template: `{{len (regex_submatch "(?P<user>\\w+)@" .)}}`,
+			data:     "none",
+			output:   "0",
+		},
+	})
+}
+
+func TestRegexInvalid(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "regex_match",
+			template: `{{regex_match "(" .}}`,
+			data:     "x",
+			err:      "missing closing ): `(`",
+		},
+		{
+			name:     "regex_find",
+			template: `{{regex_find "[" .}}`,
+			data:     "x",
+			err:      "missing closing ]: `[`",
+		},
+		{
+			name:     "regex_replace",
+			template: `{{regex_replace "(" "x" .}}`,
+			data:     "x",
+			err:      "missing closing ): `(`",
+		},
+	})
+}
+
+func TestRegexConcurrent(t *testing.T) {
+	// use more patterns than the cache size to exercise the eviction.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				pattern := fmt.Sprintf("^x%d$", (i*500+j)%300)
+				want := fmt.Sprintf("x%d", (i*500+j)%300)
+				if ok, err := tt.RegexMatch(pattern, want); !ok || err != nil {
+					t.Errorf("%s: got match=%v, err=%v, want match", pattern, ok, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,145 @@
+package template
+
+import (
+	_ "embed" // translit.txt
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// SlugOptions configures how a slug is generated.
+	SlugOptions struct {
+		// Separator is the separator of the words. Default is -.
+		Separator string
+		// MaxLength is the maximum length of the slug. The slug is cut at a word boundary
+		// when possible. Zero means no limit.
+		MaxLength int
+	}
+)
+
+var (
+	//go:embed translit.txt
+	translitData string
+
+	// translitTable maps non-ASCII letters to their ASCII transliteration.
+	translitTable = parseTranslit(translitData)
+)
+
+// Slugify return the URL and filename friendly form of s: transliterated to ASCII,
+// in lower case, with the words joined by hyphens, i.e: "Tiếng Việt!" => "tieng-viet".
+func Slugify(s string) string {
+	rs, _ := SlugifyWithOptions(s, SlugOptions{})
+	return rs
+}
+
+// SlugifyWithOptions return the slug of s using the given options.
+func SlugifyWithOptions(s string, opts SlugOptions) (string, error) {
+	if opts.MaxLength < 0 {
+		return "", fmt.Errorf("invalid max length: %d", opts.MaxLength)
+	}
+	sep := opts.Separator
+	if sep == "" {
+		sep = "-"
+	}
+	words := strings.FieldsFunc(strings.ToLower(Transliterate(s)), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	rs := strings.Join(words, sep)
+	if opts.MaxLength == 0 || len(rs) <= opts.MaxLength {
+		return rs, nil
+	}
+	n := 0
+	for i, w := range words {
+		l := len(w)
+		if i > 0 {
+			l += len(sep)
+		}
+		if n+l > opts.MaxLength {
+			break
+		}
+		n += l
+	}
+	if n == 0 {
+		// the first word is longer than the max length.
+		n = opts.MaxLength
+	}
+	return rs[:n], nil
+}
+
+// Transliterate return s with the non-ASCII letters replaced by their ASCII transliteration,
+// i.e: "Tiếng Việt" => "Tieng Viet", "Đà Nẵng" => "Da Nang", "Straße" => "Strasse".
+// Latin letters with diacritics, ligatures, Greek and Cyrillic letters are supported;
+// combining marks are removed and other characters are kept as is.
+func Transliterate(s string) string {
+	b := strings.Builder{}
+	b.Grow(len(s))
+	for _, r := range s {
+		if r <= unicode.MaxASCII {
+			b.WriteRune(r)
+			continue
+		}
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if t, ok := translitTable[r]; ok {
+			b.WriteString(t)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// slugifyWith is the template func of SlugifyWithOptions. The options can be
+// a SlugOptions or a map with the keys: separator and max_length.
+func slugifyWith(opts interface{}, s string) (string, error) {
+	o, err := toSlugOptions(opts)
+	if err != nil {
+		return "", err
+	}
+	return SlugifyWithOptions(s, o)
+}
+
+func toSlugOptions(v interface{}) (SlugOptions, error) {
+	switch o := v.(type) {
+	case SlugOptions:
+		return o, nil
+	case *SlugOptions:
+		if o != nil {
+			return *o, nil
+		}
+		return SlugOptions{}, nil
+	case nil:
+		return SlugOptions{}, nil
+	}
+	m, err := toDict(v, false)
+	if err != nil {
+		return SlugOptions{}, err
+	}
+	opts := SlugOptions{
+		Separator: fmt.Sprint(Default("", m["separator"])),
+	}
+	if val, ok := m["max_length"]; ok {
+		n, err := toNumber(val)
+		if err != nil || !n.isInt || !n.i.IsInt64() {
+			return SlugOptions{}, fmt.Errorf("invalid max_length: %v", val)
+		}
+		opts.MaxLength = int(n.i.Int64())
+	}
+	return opts, nil
+}
+
+// parseTranslit parses lines of "<letter> <replacement>". Lines starting with # are ignored.
+func parseTranslit(data string) map[rune]string {
+	m := make(map[rune]string)
+	for _, line := range strings.Split(data, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		r := []rune(fields[0])[0]
+		m[r] = strings.Join(fields[1:], "")
+	}
+	return m
+}
@@ -0,0 +1,87 @@
+package template_test
+
+import (
+	"testing"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestSlugify(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "vietnamese",
+			template: `{{slugify .}}`,
+			data:     "Tiếng Việt",
+			output:   "tieng-viet",
+		},
+		{
+			name:     "punctuation and spaces",
+			template: `{{slugify .}}`,
+			data:     "  Hello, World! -- Go 1.16  ",
+			output:   "hello-world-go-1-16",
+		},
+		{
+			name:     "separator",
+			template: `{{slugify_with (map "separator" "_") .}}`,
+			data:     "Đà Nẵng city",
+			output:   "da_nang_city",
+		},
+		{
+			name:     "max length at word boundary",
+			template: `{{slugify_with (map "max_length" 12) .}}`,
+			data:     "Thành phố Hồ Chí Minh",
+			output:   "thanh-pho-ho",
+		},
+		{
+			name:     "max length long word",
+			template: `{{slugify_with (map "max_length" 5) .}}`,
+			data:     "Supercalifragilistic",
+			output:   "super",
+		},
+		{
+			name:     "non latin",
+			template: `{{slugify .}}`,
+			data:     "Привет, Κόσμε",
+			output:   "privet-kosme",
+		},
+	})
+}
+
+func TestTransliterate(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "transliterate",
+			template: `{{transliterate .}}`,
+			data:     "Straße Æsir Łódź",
+			output:   "Strasse AEsir Lodz",
+		},
+		{
+			name:     "unaccent",
+			template: `{{unaccent .}}`,
+			data:     "Tiếng Việt",
+			output:   "Tieng Viet",
+		},
+		{
+			name:     "combining marks",
+			template: `{{unaccent .}}`,
+			data:     "Vie\u0323\u0302t",
+			output:   "Viet",
+		},
+		{
+			name:     "unknown characters are kept",
+			template: `{{transliterate .}}`,
+			data:     "日本 café",
+			output:   "日本 cafe",
+		},
+	})
+}
+
+func TestSlugifyWithOptions(t *testing.T) {
+	got, err := tt.SlugifyWithOptions("Xin chào thế giới", tt.SlugOptions{Separator: ".", MaxLength: 14})
+	if err != nil || got != "xin.chao.the" {
+		t.Errorf("got result=%s, err=%v, want result=xin.chao.the", got, err)
+	}
+	if _, err := tt.SlugifyWithOptions("x", tt.SlugOptions{MaxLength: -1}); err == nil || err.Error() != "invalid max length: -1" {
+		t.Errorf("got err=%v, want err=invalid max length: -1", err)
+	}
+}
@@ -0,0 +1,151 @@
+package template
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// timePresets are the named formats accepted by date, as Go layouts.
+	// The locale dependent presets long and short are defined in timeLocales.
+	timePresets = map[string]string{
+		"rfc3339":     time.RFC3339,
+		"rfc3339nano": time.RFC3339Nano,
+		"rfc1123":     time.RFC1123,
+		"rfc1123z":    time.RFC1123Z,
+		"rfc822":      time.RFC822,
+		"iso8601":     "2006-01-02T15:04:05.000Z07:00",
+		"kitchen":     time.Kitchen,
+		"datetime":    "2006-01-02 15:04:05",
+	}
+)
+
+// formatTime formats t using a named preset, a strftime format if it contains %
+// or otherwise a Go layout.
+func formatTime(l timeLocale, format string, t time.Time) string {
+	switch name := strings.ToLower(format); {
+	case name == "long":
+		return strftime(l, l.long, t)
+	case name == "short":
+		return strftime(l, l.short, t)
+	case timePresets[name] != "":
+		return t.Format(timePresets[name])
+	case strings.Contains(format, "%"):
+		return strftime(l, format, t)
+	}
+	return t.Format(format)
+}
+
+// strftime formats t using the C strftime directives and the names of the locale.
+// A - flag after % removes the padding, i.e: %-d.
+// Unknown directives are written as is.
+func strftime(l timeLocale, format string, t time.Time) string {
+	b := strings.Builder{}
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' || i+1 >= len(format) {
+			b.WriteByte(c)
+			continue
+		}
+		start := i
+		i++
+		pad := true
+		if format[i] == '-' && i+1 < len(format) {
+			pad = false
+			i++
+		}
+		num := func(v int, width int, padChar byte) string {
+			s := strconv.Itoa(v)
+			if pad && len(s) < width {
+				s = strings.Repeat(string(padChar), width-len(s)) + s
+			}
+			return s
+		}
+		hour12 := t.Hour() % 12
+		if hour12 == 0 {
+			hour12 = 12
+		}
+		switch format[i] {
+		case 'Y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		case 'C':
+			b.WriteString(num(t.Year()/100, 2, '0'))
+		case 'y':
+			b.WriteString(num(t.Year()%100, 2, '0'))
+		case 'm':
+			b.WriteString(num(int(t.Month()), 2, '0'))
+		case 'B':
+			b.WriteString(l.months[t.Month()-1])
+		case 'b', 'h':
+			b.WriteString(l.shortMonths[t.Month()-1])
+		case 'd':
+			b.WriteString(num(t.Day(), 2, '0'))
+		case 'e':
+			b.WriteString(num(t.Day(), 2, ' '))
+		case 'j':
+			b.WriteString(num(t.YearDay(), 3, '0'))
+		case 'H':
+			b.WriteString(num(t.Hour(), 2, '0'))
+		case 'k':
+			b.WriteString(num(t.Hour(), 2, ' '))
+		case 'I':
+			b.WriteString(num(hour12, 2, '0'))
+		case 'l':
+			b.WriteString(num(hour12, 2, ' '))
+		case 'M':
+			b.WriteString(num(t.Minute(), 2, '0'))
+		case 'S':
+			b.WriteString(num(t.Second(), 2, '0'))
+		case 'L':
+			b.WriteString(num(t.Nanosecond()/int(time.Millisecond), 3, '0'))
+		case 'f':
+			b.WriteString(num(t.Nanosecond()/int(time.Microsecond), 6, '0'))
+		case 'N':
+			b.WriteString(num(t.Nanosecond(), 9, '0'))
+		case 'p':
+			if t.Hour() < 12 {
+				b.WriteString(l.am)
+			} else {
+				b.WriteString(l.pm)
+			}
+		case 'A':
+			b.WriteString(l.days[t.Weekday()])
+		case 'a':
+			b.WriteString(l.shortDays[t.Weekday()])
+		case 'u':
+			b.WriteString(strconv.Itoa((int(t.Weekday())+6)%7 + 1))
+		case 'w':
+			b.WriteString(strconv.Itoa(int(t.Weekday())))
+		case 'V':
+			_, w := t.ISOWeek()
+			b.WriteString(num(w, 2, '0'))
+		case 'G':
+			y, _ := t.ISOWeek()
+			b.WriteString(strconv.Itoa(y))
+		case 'Z':
+			b.WriteString(t.Format("MST"))
+		case 'z':
+			b.WriteString(t.Format("-0700"))
+		case 's':
+			b.WriteString(strconv.FormatInt(t.Unix(), 10))
+		case 'F':
+			b.WriteString(strftime(l, "%Y-%m-%d", t))
+		case 'T':
+			b.WriteString(strftime(l, "%H:%M:%S", t))
+		case 'D':
+			b.WriteString(strftime(l, "%m/%d/%y", t))
+		case 'R':
+			b.WriteString(strftime(l, "%H:%M", t))
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteString(format[start : i+1])
+		}
+	}
+	return b.String()
+}
@@ -2,6 +2,8 @@ package template_test
 
 import (
 	"testing"
+
+	tt "github.com/pthethanh/template"
 )
 
 func TestStringTrim(t *testing.T) {
@@ -139,3 +141,85 @@ func TestStringCommon(t *testing.T) {
 		},
 	})
 }
+
+func TestStringCase(t *testing.T) {
+	cases := []struct {
+		in                                               string
+		snake, kebab, screaming, camel, pascal, sentence string
+	}{
+		{
+			in:    "HTTPServer",
+			snake: "http_server", kebab: "http-server", screaming: "HTTP_SERVER",
+			camel: "httpServer", pascal: "HttpServer", sentence: "Http server",
+		},
+		{
+			in:    "userID",
+			snake: "user_id", kebab: "user-id", screaming: "USER_ID",
+			camel: "userId", pascal: "UserId", sentence: "User id",
+		},
+		{
+			in:    "  foo_bar-baz.qux  ",
+			snake: "foo_bar_baz_qux", kebab: "foo-bar-baz-qux", screaming: "FOO_BAR_BAZ_QUX",
+			camel: "fooBarBazQux", pascal: "FooBarBazQux", sentence: "Foo bar baz qux",
+		},
+		{
+			in:    "base64Encode X509Cert",
+			snake: "base64_encode_x509_cert", kebab: "base64-encode-x509-cert", screaming: "BASE64_ENCODE_X509_CERT",
+			camel: "base64EncodeX509Cert", pascal: "Base64EncodeX509Cert", sentence: "Base64 encode x509 cert",
+		},
+		{
+			in:    "Tiếng Việt",
+			snake: "tiếng_việt", kebab: "tiếng-việt", screaming: "TIẾNG_VIỆT",
+			camel: "tiếngViệt", pascal: "TiếngViệt", sentence: "Tiếng việt",
+		},
+		{
+			in: "",
+		},
+	}
+	for _, c := range cases {
+		for name, got := range map[string][2]string{
+			"snake_case":      {tt.SnakeCase(c.in), c.snake},
+			"kebab_case":      {tt.KebabCase(c.in), c.kebab},
+			"screaming_snake": {tt.ScreamingSnakeCase(c.in), c.screaming},
+			"camel_case":      {tt.CamelCase(c.in), c.camel},
+			"pascal_case":     {tt.PascalCase(c.in), c.pascal},
+			"sentence_case":   {tt.SentenceCase(c.in), c.sentence},
+		} {
+			if got[0] != got[1] {
+				t.Errorf("%s %q: got result=%q, want result=%q", name, c.in, got[0], got[1])
+			}
+		}
+	}
+}
+
+func TestStringTitleSwapCase(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "title keeps acronyms",
+			template: `{{.|title}}`,
+			data:     "the HTTP api-server",
+			output:   "The HTTP Api-Server",
+		},
+		{
+			name:     "title unicode",
+			template: `{{.|title}}`,
+			data:     "ǆungla việt nam",
+			output:   "ǅungla Việt Nam",
+		},
+		{
+			name:     "swap_case",
+			template: `{{.|swap_case}}`,
+			data:     "Hello Việt",
+			output:   "hELLO vIỆT",
+		},
+		{
+			name:     "snake_case",
+			template: `{{.|snake_case}}`,
+			data:     "HTTPServer",
+			output:   "http_server",
+		},
+	})
+	if got, want := tt.Title("o'neil’s shop"), "O'neil’s Shop"; got != want {
+		t.Errorf("got result=%s, want result=%s", got, want)
+	}
+}
@@ -1,15 +1,310 @@
 package template
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
+type (
+	timeUnit struct {
+		d     time.Duration
+		name  string
+		short string
+	}
+)
+
+var (
+	errInvalidTime = errors.New("value must be a time.Time or seconds since UNIX epoch")
+
+	// timeLayouts are the layouts used to detect the format of a time string.
+	timeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999Z0700",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"2006-01-02 15:04:05Z07:00",
+		"2006-01-02 15:04:05",
+		"2006-01-02",
+		"20060102T150405Z0700",
+		"20060102",
+		time.RFC1123Z,
+		time.RFC1123,
+	}
+
+	relativeUnits = []timeUnit{
+		{d: 365 * 24 * time.Hour, name: "year", short: "y"},
+		{d: 30 * 24 * time.Hour, name: "month", short: "mo"},
+		{d: 7 * 24 * time.Hour, name: "week", short: "w"},
+		{d: 24 * time.Hour, name: "day", short: "d"},
+		{d: time.Hour, name: "hour", short: "h"},
+		{d: time.Minute, name: "minute", short: "m"},
+		{d: time.Second, name: "second", short: "s"},
+	}
+)
+
+// TimeFuncMap return time func map.
 func TimeFuncMap() map[string]interface{} {
+	return TimeFuncMapWithClock(time.Now)
+}
+
+// TimeFuncMapWithClock return time func map which uses the given func to get the current time.
+func TimeFuncMapWithClock(now func() time.Time) map[string]interface{} {
 	return map[string]interface{}{
-		"date":     FormatTime,
-		"duration": FormatDuration,
+		"date": func(fmt string, zone string, date interface{}) string {
+			return formatTimeLocale(now, defaultLocale, fmt, zone, date)
+		},
+		"date_locale": func(locale string, fmt string, zone string, date interface{}) string {
+			return formatTimeLocale(now, locale, fmt, zone, date)
+		},
+		"duration":        FormatDuration,
+		"format_duration": formatDuration,
+		"parse_duration":  ParseDuration,
+		"time_ago": func(v interface{}) (string, error) {
+			return relativeTime(v, now, HumanizeTime)
+		},
+		"humanize_time": func(v interface{}) (string, error) {
+			return relativeTime(v, now, HumanizeTime)
+		},
+		"time_ago_short": func(v interface{}) (string, error) {
+			return relativeTime(v, now, HumanizeTimeShort)
+		},
+		"time_until": func(v interface{}) (string, error) {
+			return relativeTime(v, now, TimeUntil)
+		},
+		"now":           now,
+		"parse_time":    ParseTime,
+		"add_duration":  AddDuration,
+		"add_date":      AddDate,
+		"start_of":      StartOf,
+		"end_of":        EndOf,
+		"truncate_time": TruncateTime,
+		"time_diff":     TimeDiff,
+		"unix":          Unix,
+		"in_zone":       InZone,
+	}
+}
+
+// ParseTime parses the value using the given layouts. If no layout is given,
+// the format is detected among RFC3339, ISO 8601, RFC1123 and UNIX seconds.
+// Values without time zone are parsed as UTC.
+// The value can also be a time.Time, *time.Time or an integer of seconds since UNIX epoch.
+func ParseTime(v interface{}, layouts ...string) (time.Time, error) {
+	if t, ok := toTime(v); ok {
+		return t, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, errInvalidTime
+	}
+	s = strings.TrimSpace(s)
+	if len(layouts) == 0 {
+		// 8 digits is a basic ISO 8601 date, i.e: 20060102.
+		if sec, err := strconv.ParseInt(s, 10, 64); err == nil && len(s) != len("20060102") {
+			return time.Unix(sec, 0), nil
+		}
+		layouts = timeLayouts
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as time", s)
+}
+
+// AddDuration return the time t plus the duration d.
+// The duration can be a time.Duration, a string like "1h30m" or an integer of nanoseconds.
+func AddDuration(d interface{}, t interface{}) (time.Time, error) {
+	dur, err := toDuration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tm, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.Add(dur), nil
+}
+
+// AddDate return the time t plus the given number of years, months and days.
+func AddDate(years, months, days int, t interface{}) (time.Time, error) {
+	tm, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.AddDate(years, months, days), nil
+}
+
+// StartOf return the beginning of the unit of time containing t.
+// The unit can be minute, hour, day, week (starting on Monday), month or year.
+func StartOf(unit string, t interface{}) (time.Time, error) {
+	tm, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, d := tm.Date()
+	loc := tm.Location()
+	switch strings.ToLower(unit) {
+	case "minute":
+		return time.Date(y, m, d, tm.Hour(), tm.Minute(), 0, 0, loc), nil
+	case "hour":
+		return time.Date(y, m, d, tm.Hour(), 0, 0, 0, loc), nil
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, loc), nil
+	case "week":
+		return time.Date(y, m, d-(int(tm.Weekday())+6)%7, 0, 0, 0, 0, loc), nil
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc), nil
+	case "year":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time unit: %q", unit)
+}
+
+// EndOf return the last nanosecond of the unit of time containing t.
+// The unit can be minute, hour, day, week (starting on Monday), month or year.
+func EndOf(unit string, t interface{}) (time.Time, error) {
+	start, err := StartOf(unit, t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var next time.Time
+	switch strings.ToLower(unit) {
+	case "minute":
+		next = start.Add(time.Minute)
+	case "hour":
+		next = start.Add(time.Hour)
+	case "day":
+		next = start.AddDate(0, 0, 1)
+	case "week":
+		next = start.AddDate(0, 0, 7)
+	case "month":
+		next = start.AddDate(0, 1, 0)
+	case "year":
+		next = start.AddDate(1, 0, 0)
+	}
+	return next.Add(-time.Nanosecond), nil
+}
+
+// TruncateTime return the result of rounding t down to a multiple of d since the zero time.
+// The duration can be a time.Duration, a string like "1h30m" or an integer of nanoseconds.
+func TruncateTime(d interface{}, t interface{}) (time.Time, error) {
+	dur, err := toDuration(d)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tm, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.Truncate(dur), nil
+}
+
+// TimeDiff return the duration t1-t2.
+func TimeDiff(t1, t2 interface{}) (time.Duration, error) {
+	x, err := ParseTime(t1)
+	if err != nil {
+		return 0, err
+	}
+	y, err := ParseTime(t2)
+	if err != nil {
+		return 0, err
+	}
+	return x.Sub(y), nil
+}
+
+// Unix return the number of seconds elapsed since UNIX epoch of t.
+func Unix(t interface{}) (int64, error) {
+	tm, err := ParseTime(t)
+	if err != nil {
+		return 0, err
+	}
+	return tm.Unix(), nil
+}
+
+// InZone return t in the given IANA time zone, i.e: "Asia/Ho_Chi_Minh".
+// Empty zone means the local time zone.
+func InZone(zone string, t interface{}) (time.Time, error) {
+	tm, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, err
+	}
+	loc, err := location(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return tm.In(loc), nil
+}
+
+// HumanizeTime return the time t relative to now in words,
+// i.e: "just now", "1 minute ago", "3 hours ago" or "in 2 days".
+func HumanizeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	n, unit, ok := relativeUnit(d)
+	if !ok {
+		return "just now"
+	}
+	s := plural(n, unit.name)
+	if d < 0 {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+// HumanizeTimeShort return the time t relative to now in short form,
+// i.e: "now", "3h" for 3 hours ago or "in 2d" for 2 days later.
+func HumanizeTimeShort(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	n, unit, ok := relativeUnit(d)
+	if !ok {
+		return "now"
+	}
+	s := fmt.Sprintf("%d%s", n, unit.short)
+	if d < 0 {
+		return "in " + s
+	}
+	return s
+}
+
+// TimeUntil return the time remaining from now until t in words, i.e: "2 days".
+// It returns "now" if t is less than a second after now or in the past.
+func TimeUntil(t time.Time, now time.Time) string {
+	n, unit, ok := relativeUnit(t.Sub(now))
+	if !ok || t.Before(now) {
+		return "now"
+	}
+	return plural(n, unit.name)
+}
+
+// relativeUnit return the number of the largest unit in d. It reports false if d is less than a second.
+func relativeUnit(d time.Duration) (int64, timeUnit, bool) {
+	if d < 0 {
+		d = -d
+	}
+	for _, u := range relativeUnits {
+		if d >= u.d {
+			return int64(d / u.d), u, true
+		}
+	}
+	return 0, relativeUnits[len(relativeUnits)-1], false
+}
+
+func relativeTime(v interface{}, now func() time.Time, f func(t time.Time, now time.Time) string) (string, error) {
+	t, err := ParseTime(v)
+	if err != nil {
+		return "", err
 	}
+	return f(t, now()), nil
+}
+
+func plural(n int64, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
 }
 
 // FormatTime format the given date
@@ -17,76 +312,66 @@ func TimeFuncMap() map[string]interface{} {
 // Date can be a `time.Time` or an `int, int32, int64`.
 // In the later case, it is treated as seconds since UNIX
 // epoch.
+//
+// The format can be a Go layout, i.e: `2006-01-02`, a strftime format, i.e: `%Y-%m-%d`,
+// or one of the presets: rfc3339, rfc3339nano, rfc1123, rfc1123z, rfc822, iso8601,
+// kitchen, datetime, long and short.
 func FormatTime(fmt string, zone string, date interface{}) string {
+	return FormatTimeLocale(defaultLocale, fmt, zone, date)
+}
+
+// FormatTimeLocale format the given date like FormatTime, using the month and day names
+// and the long and short formats of the locale in strftime formats and presets.
+// Supported locales are en, vi, fr, de, es, pt, it and ja; others fall back to en.
+func FormatTimeLocale(locale string, fmt string, zone string, date interface{}) string {
+	return formatTimeLocale(time.Now, locale, fmt, zone, date)
+}
+
+// formatTimeLocale is FormatTimeLocale using now as the date if date is not a time.
+func formatTimeLocale(now func() time.Time, locale string, fmt string, zone string, date interface{}) string {
 	if zone == "" {
 		zone = "Local"
 	}
-	return formatDate(fmt, date, zone)
+	return formatDate(timeLocaleOf(locale), fmt, date, zone, now)
 }
 
-func formatDate(fmt string, date interface{}, zone string) string {
-	var t time.Time
-	switch date := date.(type) {
-	default:
-		t = time.Now()
-	case time.Time:
-		t = date
-	case *time.Time:
-		t = *date
-	case int64:
-		t = time.Unix(date, 0)
-	case int:
-		t = time.Unix(int64(date), 0)
-	case int32:
-		t = time.Unix(int64(date), 0)
+func formatDate(l timeLocale, fmt string, date interface{}, zone string, now func() time.Time) string {
+	t, ok := toTime(date)
+	if !ok {
+		t = now()
 	}
 
-	loc, err := time.LoadLocation(zone)
+	loc, err := location(zone)
 	if err != nil {
-		loc, _ = time.LoadLocation("UTC")
+		loc = time.UTC
 	}
 
-	return t.In(loc).Format(fmt)
+	return formatTime(l, fmt, t.In(loc))
 }
 
-func FormatDuration(v interface{}) string {
-	d := time.Duration(0)
-	switch val := v.(type) {
-	case time.Duration:
-		d = val
-	case int64:
-		d = time.Duration(val)
-	}
-	rs := ""
-	for d > 0 {
-		switch {
-		case d.Hours() >= 2:
-			h := int(d.Hours())
-			rs += fmt.Sprintf("%d hours ", h)
-			d -= time.Duration(h) * time.Hour
-		case d.Hours() >= 1:
-			h := int(d.Hours())
-			rs += fmt.Sprintf("%d hour ", h)
-			d -= time.Duration(h) * time.Hour
-		case d.Minutes() >= 2:
-			m := int(d.Minutes())
-			rs += fmt.Sprintf("%d minutes ", m)
-			d -= time.Duration(m) * time.Minute
-		case d.Minutes() >= 1:
-			m := int(d.Minutes())
-			rs += fmt.Sprintf("%d minute ", m)
-			d -= time.Duration(m) * time.Minute
-		case d.Seconds() >= 2:
-			s := int(d.Seconds())
-			rs += fmt.Sprintf("%d seconds", s)
-			d -= time.Duration(s) * time.Second
-		case d.Seconds() >= 1:
-			s := int(d.Seconds())
-			rs += fmt.Sprintf("%d second", s)
-			d -= time.Duration(s) * time.Second
-		default:
-			return rs
+// location return the location of the given zone. Empty zone means the local time zone.
+func location(zone string) (*time.Location, error) {
+	if zone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(zone)
+}
+
+// toTime converts the given value to time. Integers are treated as seconds since UNIX epoch.
+func toTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
 		}
+	case int64:
+		return time.Unix(v, 0), true
+	case int:
+		return time.Unix(int64(v), 0), true
+	case int32:
+		return time.Unix(int64(v), 0), true
 	}
-	return rs
+	return time.Time{}, false
 }
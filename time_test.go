@@ -0,0 +1,316 @@
+package template_test
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+	"time"
+
+	tt "github.com/pthethanh/template"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2020, 5, 10, 12, 0, 0, 0, time.UTC)
+	funcs := tt.TimeFuncMapWithClock(func() time.Time { return now })
+	cases := []struct {
+		name     string
+		template string
+		data     interface{}
+		output   string
+	}{
+		{
+			name:     "just now",
+			template: `{{time_ago .}}`,
+			data:     now.Add(-500 * time.Millisecond),
+			output:   "just now",
+		},
+		{
+			name:     "1 minute ago",
+			template: `{{time_ago .}}`,
+			data:     now.Add(-90 * time.Second),
+			output:   "1 minute ago",
+		},
+		{
+			name:     "3 hours ago",
+			template: `{{humanize_time .}}`,
+			data:     now.Add(-3*time.Hour - 20*time.Minute),
+			output:   "3 hours ago",
+		},
+		{
+			name:     "in 2 days",
+			template: `{{time_ago .}}`,
+			data:     now.Add(50 * time.Hour),
+			output:   "in 2 days",
+		},
+		{
+			name:     "unix seconds",
+			template: `{{time_ago .}}`,
+			data:     now.AddDate(-2, 0, 0).Unix(),
+			output:   "2 years ago",
+		},
+		{
+			name:     "pointer",
+			template: `{{time_ago .}}`,
+			data:     func() *time.Time { t := now.AddDate(0, 0, -14); return &t }(),
+			output:   "2 weeks ago",
+		},
+		{
+			name:     "short",
+			template: `{{time_ago_short .}}`,
+			data:     now.Add(-3 * time.Hour),
+			output:   "3h",
+		},
+		{
+			name:     "short future",
+			template: `{{time_ago_short .}}`,
+			data:     now.AddDate(0, 2, 0),
+			output:   "in 2mo",
+		},
+		{
+			name:     "short now",
+			template: `{{time_ago_short .}}`,
+			data:     now,
+			output:   "now",
+		},
+		{
+			name:     "until",
+			template: `{{time_until .}}`,
+			data:     now.Add(25 * time.Hour),
+			output:   "1 day",
+		},
+		{
+			name:     "until past",
+			template: `{{time_until .}}`,
+			data:     now.Add(-time.Hour),
+			output:   "now",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(funcs).Parse(c.template))
+			buff := bytes.Buffer{}
+			if err := tmpl.Execute(&buff, c.data); err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != c.output {
+				t.Errorf("got result=%s, want result=%s", buff.String(), c.output)
+			}
+		})
+	}
+}
+
+func TestRelativeTimeInvalid(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "time_ago",
+			template: `{{time_ago .}}`,
+			data:     "yesterday",
+			err:      `cannot parse "yesterday" as time`,
+		},
+	})
+}
+
+func TestTimeCalculation(t *testing.T) {
+	now := time.Date(2020, 5, 13, 15, 4, 5, 0, time.UTC) // Wednesday
+	funcs := tt.TimeFuncMapWithClock(func() time.Time { return now })
+	cases := []struct {
+		name     string
+		template string
+		data     interface{}
+		output   string
+	}{
+		{
+			name:     "now",
+			template: `{{now|unix}}`,
+			output:   "1589382245",
+		},
+		{
+			name:     "date uses the clock",
+			template: `{{date "2006-01-02 15:04" "UTC" nil}} {{date_locale "fr" "%A" "UTC" nil}}`,
+			output:   "2020-05-13 15:04 mercredi",
+		},
+		{
+			name:     "parse rfc3339",
+			template: `{{parse_time "2020-05-13T15:04:05+07:00"|unix}}`,
+			output:   "1589357045",
+		},
+		{
+			name:     "parse date",
+			template: `{{parse_time "2020-05-13"|date "2006-01-02 15:04" "UTC"}}`,
+			output:   "2020-05-13 00:00",
+		},
+		{
+			name:     "parse basic iso8601",
+			template: `{{parse_time "20200513"|date "2006-01-02" "UTC"}}`,
+			output:   "2020-05-13",
+		},
+		{
+			name:     "parse unix",
+			template: `{{parse_time "1589382245"|date "15:04:05" "UTC"}}`,
+			output:   "15:04:05",
+		},
+		{
+			name:     "parse layout",
+			template: `{{parse_time "13/05/2020" "02/01/2006"|date "2006-01-02" "UTC"}}`,
+			output:   "2020-05-13",
+		},
+		{
+			name:     "add duration",
+			template: `{{now|add_duration "1h30m"|date "15:04" "UTC"}}`,
+			output:   "16:34",
+		},
+		{
+			name:     "add date",
+			template: `{{now|add_date 0 1 -13|date "2006-01-02" "UTC"}}`,
+			output:   "2020-05-31",
+		},
+		{
+			name:     "start of week",
+			template: `{{now|start_of "week"|date "2006-01-02 15:04:05" "UTC"}}`,
+			output:   "2020-05-11 00:00:00",
+		},
+		{
+			name:     "start of month",
+			template: `{{now|start_of "month"|date "2006-01-02 15:04:05" "UTC"}}`,
+			output:   "2020-05-01 00:00:00",
+		},
+		{
+			name:     "end of day",
+			template: `{{now|end_of "day"|date "2006-01-02 15:04:05.999" "UTC"}}`,
+			output:   "2020-05-13 23:59:59.999",
+		},
+		{
+			name:     "end of year",
+			template: `{{now|end_of "year"|date "2006-01-02 15:04:05" "UTC"}}`,
+			output:   "2020-12-31 23:59:59",
+		},
+		{
+			name:     "truncate",
+			template: `{{now|truncate_time "1h"|date "15:04:05" "UTC"}}`,
+			output:   "15:00:00",
+		},
+		{
+			name:     "time diff",
+			template: `{{time_diff now (now|start_of "day")}}`,
+			output:   "15h4m5s",
+		},
+		{
+			name:     "in zone",
+			template: `{{now|in_zone "Asia/Ho_Chi_Minh"|date "15:04" "Asia/Ho_Chi_Minh"}}`,
+			output:   "22:04",
+		},
+		{
+			name:     "relative time from string",
+			template: `{{time_ago "2020-05-13T12:04:05Z"}}`,
+			output:   "3 hours ago",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Funcs(funcs).Parse(c.template))
+			buff := bytes.Buffer{}
+			if err := tmpl.Execute(&buff, c.data); err != nil {
+				t.Fatal(err)
+			}
+			if buff.String() != c.output {
+				t.Errorf("got result=%s, want result=%s", buff.String(), c.output)
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	tm := time.Date(2020, 5, 3, 15, 4, 5, 123456789, time.UTC) // Sunday
+	testIt(t, []testCase{
+		{
+			name:     "go layout",
+			template: `{{date "2006-01-02 15:04" "UTC" .}}`,
+			data:     tm,
+			output:   "2020-05-03 15:04",
+		},
+		{
+			name:     "strftime",
+			template: `{{date "%Y-%m-%d %H:%M:%S.%L" "UTC" .}}`,
+			data:     tm,
+			output:   "2020-05-03 15:04:05.123",
+		},
+		{
+			name:     "strftime names and no padding",
+			template: `{{date "%a, %-d %b %y %I:%M %p (%A %B) %j %%" "UTC" .}}`,
+			data:     tm,
+			output:   "Sun, 3 May 20 03:04 PM (Sunday May) 124 %",
+		},
+		{
+			name:     "strftime shortcuts",
+			template: `{{date "%F %T %z" "Asia/Ho_Chi_Minh" .}}`,
+			data:     tm,
+			output:   "2020-05-03 22:04:05 &#43;0700",
+		},
+		{
+			name:     "strftime unknown directive",
+			template: `{{date "%Q %-Q" "UTC" .}}`,
+			data:     tm,
+			output:   "%Q %-Q",
+		},
+		{
+			name:     "preset rfc3339",
+			template: `{{date "rfc3339" "UTC" .}}`,
+			data:     tm,
+			output:   "2020-05-03T15:04:05Z",
+		},
+		{
+			name:     "preset iso8601",
+			template: `{{date "ISO8601" "UTC" .}}`,
+			data:     tm,
+			output:   "2020-05-03T15:04:05.123Z",
+		},
+		{
+			name:     "preset kitchen",
+			template: `{{date "kitchen" "UTC" .}}`,
+			data:     tm,
+			output:   "3:04PM",
+		},
+		{
+			name:     "preset long",
+			template: `{{date "long" "UTC" .}}`,
+			data:     tm,
+			output:   "May 3, 2020",
+		},
+		{
+			name:     "preset short",
+			template: `{{date "short" "UTC" .}}`,
+			data:     tm,
+			output:   "5/3/20",
+		},
+		{
+			name:     "locale vi long",
+			template: `{{date_locale "vi" "long" "UTC" .}}`,
+			data:     tm,
+			output:   "3 tháng 5, 2020",
+		},
+		{
+			name:     "locale fr strftime",
+			template: `{{date_locale "fr-FR" "%A %-d %B %Y" "UTC" .}}`,
+			data:     tm,
+			output:   "dimanche 3 mai 2020",
+		},
+		{
+			name:     "locale de short",
+			template: `{{date_locale "de" "short" "UTC" .}}`,
+			data:     tm,
+			output:   "03.05.20",
+		},
+		{
+			name:     "locale ja",
+			template: `{{date_locale "ja" "long %a %p" "UTC" .}}`,
+			data:     tm,
+			output:   "long 日 午後",
+		},
+		{
+			name:     "unknown locale fallback",
+			template: `{{date_locale "xx" "%B" "UTC" .}}`,
+			data:     tm,
+			output:   "May",
+		},
+	})
+}
@@ -0,0 +1,299 @@
+package template
+
+import (
+	"html/template"
+	"strings"
+	"unicode"
+)
+
+type (
+	// htmlToken is a tag, an entity or a grapheme cluster of text of an HTML fragment.
+	htmlToken struct {
+		s string
+		// tag is the lower case name of the tag, empty for text and entities.
+		tag     string
+		closing bool
+		void    bool
+	}
+)
+
+const (
+	defaultEllipsis = "..."
+	zeroWidthJoiner = '\u200d'
+)
+
+var (
+	voidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+		"input": true, "link": true, "meta": true, "param": true, "source": true, "track": true, "wbr": true,
+	}
+)
+
+// Truncate return the first n characters of s. Characters are grapheme clusters,
+// so that letters with combining marks and emoji sequences are never split.
+func Truncate(n int, s string) string {
+	gs := graphemes(s)
+	if n >= len(gs) {
+		return s
+	}
+	if n <= 0 {
+		return ""
+	}
+	return strings.Join(gs[:n], "")
+}
+
+// Abbrev return s abbreviated to n characters including the ... ellipsis,
+// i.e: abbrev 8 "Hello world" => "Hello...".
+func Abbrev(n int, s string) string {
+	return AbbrevWith(defaultEllipsis, n, s)
+}
+
+// AbbrevWith return s abbreviated to n characters including the given ellipsis,
+// i.e: abbrev_with "…" 6 "Hello world" => "Hello…".
+// If n is not greater than the length of the ellipsis, s is truncated without ellipsis.
+func AbbrevWith(ellipsis string, n int, s string) string {
+	gs := graphemes(s)
+	if n >= len(gs) {
+		return s
+	}
+	keep := n - len(graphemes(ellipsis))
+	if keep <= 0 {
+		return Truncate(n, s)
+	}
+	return strings.TrimRightFunc(strings.Join(gs[:keep], ""), unicode.IsSpace) + ellipsis
+}
+
+// AbbrevMiddle return s abbreviated to n characters by replacing its middle with the ... ellipsis,
+// i.e: abbrev_middle 11 "0123456789abcdef" => "0123...cdef".
+func AbbrevMiddle(n int, s string) string {
+	gs := graphemes(s)
+	if n >= len(gs) {
+		return s
+	}
+	keep := n - len(graphemes(defaultEllipsis))
+	if keep <= 0 {
+		return Truncate(n, s)
+	}
+	head, tail := (keep+1)/2, keep/2
+	return strings.Join(gs[:head], "") + defaultEllipsis + strings.Join(gs[len(gs)-tail:], "")
+}
+
+// TruncateWords return the first n words of s followed by the ... ellipsis if s has more words,
+// i.e: truncate_words 2 "The quick brown fox" => "The quick...".
+func TruncateWords(n int, s string) string {
+	if n <= 0 {
+		return ""
+	}
+	inWord := false
+	words := 0
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			inWord = false
+			continue
+		}
+		if inWord {
+			continue
+		}
+		inWord = true
+		words++
+		if words > n {
+			return strings.TrimRightFunc(s[:i], unicode.IsSpace) + defaultEllipsis
+		}
+	}
+	return s
+}
+
+// TruncateHTML return the HTML fragment v truncated to n visible characters if v is a template.HTML.
+// Tags and entities are never split, entities count as one character
+// and the tags left open are closed.
+// Any other value is untrusted: it is truncated as plain text using Truncate
+// and returned as a string, so that it is escaped by html/template.
+func TruncateHTML(n int, v interface{}) interface{} {
+	if h, ok := v.(template.HTML); ok {
+		return template.HTML(abbrevHTML("", n, string(h)))
+	}
+	return Truncate(n, evalArgs([]interface{}{v}))
+}
+
+// AbbrevHTML return the HTML fragment v abbreviated to n visible characters including the ... ellipsis
+// if v is a template.HTML. Tags and entities are never split, entities count as one character
+// and the tags left open are closed.
+// Any other value is untrusted: it is abbreviated as plain text using Abbrev
+// and returned as a string, so that it is escaped by html/template.
+func AbbrevHTML(n int, v interface{}) interface{} {
+	if h, ok := v.(template.HTML); ok {
+		return template.HTML(abbrevHTML(defaultEllipsis, n, string(h)))
+	}
+	return Abbrev(n, evalArgs([]interface{}{v}))
+}
+
+func abbrevHTML(ellipsis string, n int, s string) string {
+	tokens := htmlTokens(s)
+	visible := 0
+	for _, t := range tokens {
+		if t.tag == "" {
+			visible++
+		}
+	}
+	if n >= visible {
+		return s
+	}
+	keep := n - len(graphemes(ellipsis))
+	if keep < 0 {
+		keep, ellipsis = n, ""
+	}
+	b := strings.Builder{}
+	open := make([]string, 0)
+	count := 0
+	for _, t := range tokens {
+		if t.tag == "" {
+			if count == keep {
+				break
+			}
+			count++
+			b.WriteString(t.s)
+			continue
+		}
+		b.WriteString(t.s)
+		switch {
+		case t.void:
+		case t.closing:
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == t.tag {
+					open = open[:i]
+					break
+				}
+			}
+		default:
+			open = append(open, t.tag)
+		}
+	}
+	b.WriteString(ellipsis)
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+// htmlTokens splits the HTML fragment into tags, entities and grapheme clusters of text.
+// Comments, doctypes and processing instructions are treated as void tags.
+func htmlTokens(s string) []htmlToken {
+	tokens := make([]htmlToken, 0)
+	for s != "" {
+		switch {
+		case strings.HasPrefix(s, "<!--"):
+			end := strings.Index(s, "-->")
+			if end < 0 {
+				end = len(s)
+			} else {
+				end += len("-->")
+			}
+			tokens = append(tokens, htmlToken{s: s[:end], tag: "!", void: true})
+			s = s[end:]
+			continue
+		case len(s) > 1 && s[0] == '<' && (isASCIILetter(s[1]) || s[1] == '/' || s[1] == '!' || s[1] == '?'):
+			end := strings.IndexByte(s, '>')
+			if end < 0 {
+				end = len(s) - 1
+			}
+			tokens = append(tokens, newHTMLTag(s[:end+1]))
+			s = s[end+1:]
+			continue
+		case s[0] == '&':
+			if end := strings.IndexByte(s, ';'); end > 1 && end < 32 && isEntityName(s[1:end]) {
+				tokens = append(tokens, htmlToken{s: s[:end+1]})
+				s = s[end+1:]
+				continue
+			}
+		}
+		g := firstGrapheme(s)
+		tokens = append(tokens, htmlToken{s: g})
+		s = s[len(g):]
+	}
+	return tokens
+}
+
+func newHTMLTag(s string) htmlToken {
+	t := htmlToken{s: s}
+	name := strings.TrimPrefix(s, "<")
+	if strings.HasPrefix(name, "!") || strings.HasPrefix(name, "?") {
+		t.tag, t.void = name[:1], true
+		return t
+	}
+	if strings.HasPrefix(name, "/") {
+		t.closing = true
+		name = name[1:]
+	}
+	end := strings.IndexFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == '/' || r == '>' })
+	if end >= 0 {
+		name = name[:end]
+	}
+	t.tag = strings.ToLower(name)
+	t.void = !t.closing && (voidElements[t.tag] || strings.HasSuffix(s, "/>"))
+	return t
+}
+
+func isEntityName(s string) bool {
+	if strings.HasPrefix(s, "#") {
+		s = s[1:]
+	}
+	for i := 0; i < len(s); i++ {
+		if !isASCIILetter(s[i]) && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// graphemes splits s into grapheme clusters.
+func graphemes(s string) []string {
+	gs := make([]string, 0, len(s))
+	for s != "" {
+		g := firstGrapheme(s)
+		gs = append(gs, g)
+		s = s[len(g):]
+	}
+	return gs
+}
+
+// firstGrapheme return the first grapheme cluster of s. It is an approximation of the
+// Unicode rules which keeps together a character and its combining marks, CRLF,
+// emoji modifiers, zero width joiner sequences and regional indicator pairs (flags).
+func firstGrapheme(s string) string {
+	var prev rune
+	regional := 0
+	for i, r := range s {
+		if i > 0 && !extendsGrapheme(prev, r, regional) {
+			return s[:i]
+		}
+		if isRegionalIndicator(r) {
+			regional++
+		}
+		prev = r
+	}
+	return s
+}
+
+func extendsGrapheme(prev rune, r rune, regional int) bool {
+	switch {
+	case prev == '\r':
+		return r == '\n'
+	case prev == zeroWidthJoiner:
+		return true
+	case unicode.IsMark(r), r == zeroWidthJoiner:
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // emoji skin tone modifiers.
+		return true
+	case isRegionalIndicator(r):
+		return isRegionalIndicator(prev) && regional%2 == 1
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
@@ -0,0 +1,118 @@
+package template_test
+
+import (
+	"html/template"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "truncate runes",
+			template: `{{truncate 5 .}}`,
+			data:     "Tiếng Việt",
+			output:   "Tiếng",
+		},
+		{
+			name:     "truncate combining marks",
+			template: `{{truncate 3 .}}`,
+			data:     "Vie\u0323\u0302t Nam",
+			output:   "Vie\u0323\u0302",
+		},
+		{
+			name:     "truncate emoji sequences",
+			template: `{{truncate 2 .}}`,
+			data:     "\U0001F44D\U0001F3FD\U0001F1FB\U0001F1F3\U0001F468\u200d\U0001F469\u200d\U0001F467",
+			output:   "\U0001F44D\U0001F3FD\U0001F1FB\U0001F1F3",
+		},
+		{
+			name:     "truncate short",
+			template: `{{truncate 20 .}}`,
+			data:     "hello",
+			output:   "hello",
+		},
+		{
+			name:     "abbrev",
+			template: `{{abbrev 8 .}}`,
+			data:     "Hello world",
+			output:   "Hello...",
+		},
+		{
+			name:     "abbrev trims spaces",
+			template: `{{abbrev 9 .}}`,
+			data:     "Hello world",
+			output:   "Hello...",
+		},
+		{
+			name:     "abbrev_with",
+			template: `{{abbrev_with "…" 6 .}}`,
+			data:     "Xin chào thế giới",
+			output:   "Xin c…",
+		},
+		{
+			name:     "abbrev shorter than ellipsis",
+			template: `{{abbrev 2 .}}`,
+			data:     "Hello",
+			output:   "He",
+		},
+		{
+			name:     "abbrev_middle",
+			template: `{{abbrev_middle 11 .}}`,
+			data:     "0123456789abcdef",
+			output:   "0123...cdef",
+		},
+		{
+			name:     "truncate_words",
+			template: `{{truncate_words 2 .}}`,
+			data:     "The  quick brown fox",
+			output:   "The  quick...",
+		},
+		{
+			name:     "truncate_words short",
+			template: `{{truncate_words 4 .}}`,
+			data:     "The quick brown fox",
+			output:   "The quick brown fox",
+		},
+	})
+}
+
+func TestTruncateHTML(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "truncate_html closes tags",
+			template: `{{truncate_html 7 .}}`,
+			data:     template.HTML(`<p>Hello <b>world</b>!</p>`),
+			output:   `<p>Hello <b>w</b></p>`,
+		},
+		{
+			name:     "truncate_html entities",
+			template: `{{truncate_html 3 .}}`,
+			data:     template.HTML(`a&amp;b&#39;c`),
+			output:   `a&amp;b`,
+		},
+		{
+			name:     "abbrev_html",
+			template: `{{abbrev_html 8 .}}`,
+			data:     template.HTML(`<div><a href="/x">Hello</a><br> world<img src="x.png"/></div>`),
+			output:   `<div><a href="/x">Hello</a><br>...</div>`,
+		},
+		{
+			name:     "abbrev_html short",
+			template: `{{abbrev_html 20 .}}`,
+			data:     template.HTML(`<i>Hello</i> <!-- note -->world`),
+			output:   `<i>Hello</i> <!-- note -->world`,
+		},
+		{
+			name:     "truncate_html untrusted",
+			template: `{{truncate_html 5 .}}`,
+			data:     `<script>alert(1)</script>hello`,
+			output:   `&lt;scri`,
+		},
+		{
+			name:     "abbrev_html untrusted",
+			template: `{{abbrev_html 7 .}}`,
+			data:     `<b>x</b>hello`,
+			output:   `&lt;b&gt;x...`,
+		},
+	})
+}
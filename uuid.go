@@ -0,0 +1,106 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	uuidNamespaces = map[string]uuid.UUID{
+		"dns":  uuid.NameSpaceDNS,
+		"url":  uuid.NameSpaceURL,
+		"oid":  uuid.NameSpaceOID,
+		"x500": uuid.NameSpaceX500,
+	}
+)
+
+// UUIDFuncMap return uuid func map.
+func UUIDFuncMap() map[string]interface{} {
+	return UUIDFuncMapWithGenerator(newUUID)
+}
+
+// UUIDFuncMapWithGenerator return uuid func map using gen to generate the random (version 4)
+// and the time-ordered (version 7) UUIDs, i.e: to return fixed values in golden-file tests.
+// Name-based UUIDs (version 3 and 5) are deterministic and don't use gen.
+func UUIDFuncMapWithGenerator(gen func(version int) (string, error)) map[string]interface{} {
+	return map[string]interface{}{
+		// uuid overrides the one of GeneralFuncMap so that gen is used.
+		"uuid":       func() (string, error) { return gen(4) },
+		"uuid_v4":    func() (string, error) { return gen(4) },
+		"uuid_v7":    func() (string, error) { return gen(7) },
+		"uuid_v3":    UUIDv3,
+		"uuid_v5":    UUIDv5,
+		"uuid_parse": ParseUUID,
+		"uuid_valid": IsUUID,
+	}
+}
+
+// UUIDv3 return the name-based UUID version 3 (MD5) of name in the given namespace.
+// The namespace is dns, url, oid, x500 or a UUID.
+func UUIDv3(namespace string, name string) (string, error) {
+	ns, err := uuidNamespace(namespace)
+	if err != nil {
+		return "", err
+	}
+	return uuid.NewMD5(ns, []byte(name)).String(), nil
+}
+
+// UUIDv5 return the name-based UUID version 5 (SHA-1) of name in the given namespace.
+// The namespace is dns, url, oid, x500 or a UUID.
+func UUIDv5(namespace string, name string) (string, error) {
+	ns, err := uuidNamespace(namespace)
+	if err != nil {
+		return "", err
+	}
+	return uuid.NewSHA1(ns, []byte(name)).String(), nil
+}
+
+// UUIDv7 return a time-ordered UUID version 7.
+func UUIDv7() (string, error) {
+	return newUUID(7)
+}
+
+// ParseUUID parses a UUID in standard, URN (urn:uuid:), braced or hex form
+// and return it in the standard lowercase form.
+func ParseUUID(s string) (string, error) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// IsUUID report whether s is a valid UUID.
+func IsUUID(s string) bool {
+	return uuid.Validate(s) == nil
+}
+
+func newUUID(version int) (string, error) {
+	var id uuid.UUID
+	var err error
+	switch version {
+	case 4:
+		id, err = uuid.NewRandom()
+	case 7:
+		id, err = uuid.NewV7()
+	default:
+		err = fmt.Errorf("unsupported uuid version: %d", version)
+	}
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+func uuidNamespace(namespace string) (uuid.UUID, error) {
+	if ns, ok := uuidNamespaces[strings.ToLower(namespace)]; ok {
+		return ns, nil
+	}
+	ns, err := uuid.Parse(namespace)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid uuid namespace %q: %w", namespace, err)
+	}
+	return ns, nil
+}
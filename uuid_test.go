@@ -0,0 +1,113 @@
+package template_test
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"testing"
+
+	"github.com/google/uuid"
+	tt "github.com/pthethanh/template"
+)
+
+func TestUUIDVersions(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "uuid_v3",
+			template: `{{uuid_v3 "dns" "www.example.com"}}`,
+			output:   "5df41881-3aed-3515-88a7-2f4a814cf09e",
+		},
+		{
+			name:     "uuid_v5",
+			template: `{{uuid_v5 "DNS" "www.example.com"}}`,
+			output:   "2ed6657d-e927-568b-95e1-2665a8aea6a2",
+		},
+		{
+			name:     "uuid_v5 custom namespace",
+			template: `{{uuid_v5 "2ed6657d-e927-568b-95e1-2665a8aea6a2" "x"}}`,
+			output:   uuid.NewSHA1(uuid.MustParse("2ed6657d-e927-568b-95e1-2665a8aea6a2"), []byte("x")).String(),
+		},
+		{
+			name:       "uuid_v4",
+			template:   `{{uuid_v4}}`,
+			verifyFunc: verifyUUIDVersion(4),
+		},
+		{
+			name:       "uuid_v7",
+			template:   `{{uuid_v7}}`,
+			verifyFunc: verifyUUIDVersion(7),
+		},
+		{
+			name:     "uuid_parse",
+			template: `{{uuid_parse "urn:uuid:2ED6657D-E927-568B-95E1-2665A8AEA6A2"}}`,
+			output:   "2ed6657d-e927-568b-95e1-2665a8aea6a2",
+		},
+		{
+			name:     "uuid_valid",
+			template: `{{uuid_valid "2ed6657d-e927-568b-95e1-2665a8aea6a2"}} {{uuid_valid "2ed6657d"}}`,
+			output:   "true false",
+		},
+	})
+}
+
+func TestUUIDv7Order(t *testing.T) {
+	prev, err := tt.UUIDv7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		id, err := tt.UUIDv7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= prev {
+			t.Fatalf("got %s after %s, want increasing UUIDs", id, prev)
+		}
+		prev = id
+	}
+}
+
+func TestUUIDInGeneralFuncMap(t *testing.T) {
+	if _, ok := tt.GeneralFuncMap()["uuid"]; !ok {
+		t.Error("got no uuid in GeneralFuncMap, want uuid")
+	}
+}
+
+func TestUUIDWithGenerator(t *testing.T) {
+	gen := func(version int) (string, error) {
+		return fmt.Sprintf("00000000-0000-%d000-8000-000000000000", version), nil
+	}
+	tmpl := template.Must(template.New("").Funcs(tt.FuncMap()).Funcs(tt.UUIDFuncMapWithGenerator(gen)).Parse(`{{uuid}} {{uuid_v7}}`))
+	buf := bytes.Buffer{}
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := "00000000-0000-4000-8000-000000000000 00000000-0000-7000-8000-000000000000"; buf.String() != want {
+		t.Errorf("got result=%s, want result=%s", buf.String(), want)
+	}
+}
+
+func TestUUIDError(t *testing.T) {
+	testIt(t, []testCase{
+		{
+			name:     "unknown namespace",
+			template: `{{uuid_v5 "unknown" "x"}}`,
+			err:      `invalid uuid namespace "unknown"`,
+		},
+		{
+			name:     "invalid uuid",
+			template: `{{uuid_parse "x"}}`,
+			err:      "invalid UUID length: 1",
+		},
+	})
+}
+
+func verifyUUIDVersion(version uuid.Version) func(string) error {
+	return func(got string) error {
+		id, err := uuid.Parse(got)
+		if err != nil || id.Version() != version {
+			return fmt.Errorf("got result=%s, want result is an UUID version %d", got, version)
+		}
+		return nil
+	}
+}